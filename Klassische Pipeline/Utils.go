@@ -416,36 +416,12 @@ func brandMatchesOrgAcronym(domain string, org string) bool {
 	return acr != "" && brand == acr
 }
 
-// stricter symbolic extractor: needs '.' or 'dot' in raw, forbids 1-char labels,
-// and requires MX OR brand≈org acronym.
+// stricter symbolic extractor: Kandidaten kommen aus der Obfuskations-Grammatik
+// (symbolic.go), 1-Zeichen-Labels sind verboten und es braucht MX ODER brand≈org acronym.
 func extractSymbolicEmailsStrict(raw string, org string) []string {
-	// must contain explicit dot indicator
-	if !strings.Contains(raw, ".") && !strings.Contains(strings.ToLower(raw), "dot") {
-		return nil
-	}
-	re := regexp.MustCompile(`(?i)([a-z0-9._+\-]{1,64})\s*(?:\(|\[)?\s*at\s*(?:\)|\])?\s*([a-z0-9.\-\s\[\]\(\)]{1,200})`)
 	out := []string{}
-	for _, m := range re.FindAllStringSubmatch(raw, -1) {
-		local := strings.ToLower(strings.TrimSpace(m[1]))
-		if !reLocalOK.MatchString(local) {
-			continue
-		}
-
-		d := strings.ToLower(m[2])
-		// normalize dot words -> '.'
-		for _, r := range []string{" (dot) ", " dot ", "[dot]", "(dot)", " dot", "dot ", " DOT ", " Dot "} {
-			d = strings.ReplaceAll(d, r, ".")
-		}
-		d = strings.ReplaceAll(d, " ", "")
-		d = strings.ReplaceAll(d, "[.]", ".")
-		d = strings.ReplaceAll(d, "(.)", ".")
-		d = regexp.MustCompile(`\.{2,}`).ReplaceAllString(d, ".")
-		d = strings.Trim(d, ".")
-
-		// domain syntax
-		if !validDomain(d) {
-			continue
-		}
+	for _, em := range extractObfuscatedEmails(raw) {
+		_, d := splitEmail(em)
 
 		// forbid 1-char labels (kills "ph.d.thesisresearch"-Art)
 		bad := false
@@ -464,7 +440,7 @@ func extractSymbolicEmailsStrict(raw string, org string) []string {
 			continue
 		}
 
-		out = append(out, local+"@"+d)
+		out = append(out, em)
 	}
 	return out
 }
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/browser"
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/chromedp"
+)
+
+// =================== Headless-Browser-Pool ===================
+//
+// Ein langlebiger Chrome-Prozess (ein Allocator) für den ganzen Lauf statt
+// eines neuen Browsers je URL. Seiten werden in wiederverwendeten Tabs
+// gerendert; die Zahl gleichzeitiger Tabs ist begrenzt (--browser-tabs).
+// Vor der Vergabe eines Tabs wird der Browser regelmäßig per CDP angepingt;
+// reagiert er nicht mehr, wird er beendet und neu gestartet.
+
+const (
+	browserStartTimeout   = 20 * time.Second
+	browserHealthInterval = 30 * time.Second
+	browserHealthTimeout  = 3 * time.Second
+	maxTabUses            = 25 // danach wird der Tab geschlossen (Speicher)
+)
+
+type poolTab struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	gen    int // Browser-Generation, zu der der Tab gehört
+	uses   int
+}
+
+type browserPool struct {
+	mu            sync.Mutex
+	slots         chan struct{} // Nebenläufigkeitslimit
+	allocCancel   context.CancelFunc
+	browserCtx    context.Context
+	browserCancel context.CancelFunc
+	gen           int
+	lastCheck     time.Time
+	idle          []*poolTab
+	jobs          int
+	restarts      int
+}
+
+var headless struct {
+	once sync.Once
+	pool *browserPool
+}
+
+// headlessPool liefert den gemeinsamen Pool (Browser startet erst beim ersten Tab).
+func headlessPool() *browserPool {
+	headless.once.Do(func() {
+		headless.pool = &browserPool{slots: make(chan struct{}, maxInt(1, runCfg.BrowserTabs))}
+	})
+	return headless.pool
+}
+
+// closeHeadlessPool beendet Tabs und Browser am Ende des Laufs.
+func closeHeadlessPool() {
+	p := headless.pool
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.browserCtx != nil {
+		fmt.Printf("🧹 Headless-Browser beendet (%d Seiten, %d Neustarts)\n", p.jobs, p.restarts)
+	}
+	p.stopLocked()
+}
+
+// withTab führt fn in einem Tab des Pools aus; ctx läuft nach timeout ab.
+func (p *browserPool) withTab(timeout time.Duration, fn func(ctx context.Context) error) error {
+	p.slots <- struct{}{}
+	defer func() { <-p.slots }()
+
+	tab, err := p.acquire()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(tab.ctx, timeout)
+	err = fn(ctx)
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err() // Zeit abgelaufen: Tab-Zustand unklar → nicht wiederverwenden
+	}
+	cancel()
+	p.release(tab, err)
+	return err
+}
+
+func (p *browserPool) acquire() (*poolTab, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.ensureBrowserLocked(); err != nil {
+		return nil, err
+	}
+	for len(p.idle) > 0 {
+		t := p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+		if t.gen == p.gen && t.ctx.Err() == nil {
+			return t, nil
+		}
+		t.cancel()
+	}
+	// neuer Tab im laufenden Browser (erster Run legt das Target an)
+	tctx, tcancel := chromedp.NewContext(p.browserCtx)
+	if err := chromedp.Run(tctx); err != nil {
+		tcancel()
+		p.lastCheck = time.Time{}
+		return nil, fmt.Errorf("tab: %w", err)
+	}
+	return &poolTab{ctx: tctx, cancel: tcancel, gen: p.gen}, nil
+}
+
+// release gibt den Tab zurück. Nach Fehlern wird er geschlossen und der
+// Browser beim nächsten acquire geprüft.
+func (p *browserPool) release(t *poolTab, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.jobs++
+	t.uses++
+	if err != nil {
+		p.lastCheck = time.Time{}
+	}
+	if err != nil || t.gen != p.gen || t.uses >= maxTabUses || t.ctx.Err() != nil {
+		t.cancel()
+		return
+	}
+	p.idle = append(p.idle, t)
+}
+
+// ensureBrowserLocked startet den Browser bzw. startet ihn nach fehlgeschlagenem Health-Check neu.
+func (p *browserPool) ensureBrowserLocked() error {
+	if p.browserCtx != nil {
+		if p.browserCtx.Err() == nil && time.Since(p.lastCheck) < browserHealthInterval {
+			return nil
+		}
+		if p.browserCtx.Err() == nil && p.pingLocked() == nil {
+			p.lastCheck = time.Now()
+			return nil
+		}
+		fmt.Println("🩺 Headless-Browser reagiert nicht – Neustart")
+		p.stopLocked()
+		p.restarts++
+	}
+	return p.startLocked()
+}
+
+func (p *browserPool) startLocked() error {
+	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(), chromedp.DefaultExecAllocatorOptions[:]...)
+	bctx, bcancel := chromedp.NewContext(allocCtx)
+
+	// erster Run startet den Prozess; darf nicht unter einem Timeout-Kontext laufen
+	errc := make(chan error, 1)
+	go func() { errc <- chromedp.Run(bctx) }()
+	select {
+	case err := <-errc:
+		if err != nil {
+			bcancel()
+			allocCancel()
+			return fmt.Errorf("browserstart: %w", err)
+		}
+	case <-time.After(browserStartTimeout):
+		bcancel()
+		allocCancel()
+		return errors.New("browserstart: timeout")
+	}
+
+	p.allocCancel, p.browserCtx, p.browserCancel = allocCancel, bctx, bcancel
+	p.gen++
+	p.lastCheck = time.Now()
+	return nil
+}
+
+// stopLocked schließt alle Tabs und beendet den Chrome-Prozess.
+func (p *browserPool) stopLocked() {
+	for _, t := range p.idle {
+		t.cancel()
+	}
+	p.idle = nil
+	if p.browserCancel != nil {
+		p.browserCancel()
+	}
+	if p.allocCancel != nil {
+		p.allocCancel()
+	}
+	p.allocCancel, p.browserCtx, p.browserCancel = nil, nil, nil
+}
+
+// pingLocked: Browser.getVersion als Lebenszeichen.
+func (p *browserPool) pingLocked() error {
+	c := chromedp.FromContext(p.browserCtx)
+	if c == nil || c.Browser == nil {
+		return errors.New("kein Browser")
+	}
+	ctx, cancel := context.WithTimeout(p.browserCtx, browserHealthTimeout)
+	defer cancel()
+	_, _, _, _, _, err := browser.GetVersion().Do(cdp.WithExecutor(ctx, c.Browser))
+	return err
+}
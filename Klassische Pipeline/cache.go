@@ -0,0 +1,287 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// =================== HTTP-Cache auf Platte ===================
+//
+// Inhaltsadressierter Cache (SHA-256 über Art + URL) für DDG-Suche, Colly,
+// Chromedp (gerendertes DOM) und PDF-Downloads. Mit TTL und Größenlimit;
+// im --offline-Modus wird nur aus dem Cache gelesen (auch abgelaufene
+// Einträge), damit Evaluations-Läufe schnell und deterministisch sind.
+
+const maxCacheBodyBytes = 16 << 20 // größere Antworten werden nicht gecacht
+
+// Request-Arten (Teil des Schlüssels, eigenes Unterverzeichnis).
+const (
+	cacheKindSearch   = "ddg"
+	cacheKindPage     = "page"
+	cacheKindRendered = "rendered"
+	cacheKindPDF      = "pdf"
+	cacheKindAux      = "aux"   // robots.txt, Sitemaps, vCards
+	cacheKindSniff    = "sniff" // erkannter Dokumenttyp je URL (docsniff.go)
+)
+
+// errOfflineMiss: Offline-Modus und kein Cache-Eintrag vorhanden.
+var errOfflineMiss = errors.New("offline: nicht im Cache")
+
+type cacheMeta struct {
+	URL         string    `json:"url"`
+	Kind        string    `json:"kind"`
+	Status      int       `json:"status"`
+	ContentType string    `json:"content_type,omitempty"`
+	Location    string    `json:"location,omitempty"` // für gecachte Redirects
+	Stored      time.Time `json:"stored"`
+}
+
+type cacheEntry struct {
+	Meta cacheMeta
+	Body []byte
+}
+
+var diskCache = struct {
+	sync.Mutex
+	size     int64 // aktuelle Gesamtgröße (lazy beim ersten Put ermittelt)
+	measured bool
+}{}
+
+func cacheEnabled() bool {
+	return runCfg.CacheEnabled || runCfg.Offline
+}
+
+func cachePath(kind, rawURL string) string {
+	sum := sha256.Sum256([]byte(kind + "\n" + rawURL))
+	h := hex.EncodeToString(sum[:])
+	return filepath.Join(runCfg.CacheDir, kind, h[:2], h)
+}
+
+// cacheGet liefert einen gültigen Eintrag (TTL; offline auch abgelaufene).
+func cacheGet(kind, rawURL string) (*cacheEntry, bool) {
+	if !cacheEnabled() {
+		return nil, false
+	}
+	f, err := os.Open(cachePath(kind, rawURL))
+	if err != nil {
+		return nil, false
+	}
+	defer f.Close()
+	br := bufio.NewReader(f)
+	line, err := br.ReadBytes('\n')
+	if err != nil {
+		return nil, false
+	}
+	var meta cacheMeta
+	if json.Unmarshal(line, &meta) != nil || meta.URL != rawURL {
+		return nil, false
+	}
+	if !runCfg.Offline && runCfg.CacheTTL > 0 && time.Since(meta.Stored) > runCfg.CacheTTL {
+		return nil, false
+	}
+	body, err := io.ReadAll(br)
+	if err != nil {
+		return nil, false
+	}
+	return &cacheEntry{Meta: meta, Body: body}, true
+}
+
+// cachePut speichert atomar (tmp + rename) und hält das Größenlimit ein.
+func cachePut(kind, rawURL string, status int, contentType, location string, body []byte) {
+	if !cacheEnabled() || runCfg.Offline || len(body) > maxCacheBodyBytes {
+		return
+	}
+	meta, err := json.Marshal(cacheMeta{
+		URL: rawURL, Kind: kind, Status: status,
+		ContentType: contentType, Location: location, Stored: time.Now(),
+	})
+	if err != nil {
+		return
+	}
+	path := cachePath(kind, rawURL)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp_*")
+	if err != nil {
+		return
+	}
+	_, werr := tmp.Write(append(meta, '\n'))
+	if werr == nil {
+		_, werr = tmp.Write(body)
+	}
+	cerr := tmp.Close()
+	if werr != nil || cerr != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	var oldSize int64
+	if st, err := os.Stat(path); err == nil {
+		oldSize = st.Size()
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	cacheAccount(int64(len(meta)+1+len(body)) - oldSize)
+}
+
+// cacheAccount führt die Gesamtgröße nach und räumt bei Überschreitung
+// die ältesten Einträge ab (bis 90 % des Limits).
+func cacheAccount(delta int64) {
+	limit := int64(runCfg.CacheMaxMB) << 20
+	if limit <= 0 {
+		return
+	}
+	diskCache.Lock()
+	defer diskCache.Unlock()
+	if !diskCache.measured {
+		diskCache.size = 0
+		_ = filepath.WalkDir(runCfg.CacheDir, func(_ string, d fs.DirEntry, err error) error {
+			if err == nil && !d.IsDir() {
+				if info, ierr := d.Info(); ierr == nil {
+					diskCache.size += info.Size()
+				}
+			}
+			return nil
+		})
+		diskCache.measured = true
+	} else {
+		diskCache.size += delta
+	}
+	if diskCache.size <= limit {
+		return
+	}
+
+	type file struct {
+		path string
+		size int64
+		mod  time.Time
+	}
+	var files []file
+	_ = filepath.WalkDir(runCfg.CacheDir, func(p string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			if info, ierr := d.Info(); ierr == nil {
+				files = append(files, file{p, info.Size(), info.ModTime()})
+			}
+		}
+		return nil
+	})
+	sort.Slice(files, func(i, j int) bool { return files[i].mod.Before(files[j].mod) })
+	target := limit * 9 / 10
+	for _, f := range files {
+		if diskCache.size <= target {
+			break
+		}
+		if os.Remove(f.path) == nil {
+			diskCache.size -= f.size
+		}
+	}
+}
+
+// -------------------- http.RoundTripper --------------------
+
+// cachingTransport beantwortet GET-Anfragen aus dem Cache und speichert
+// 200er-Antworten sowie Redirects. Andere Methoden gehen durch (offline: Fehler).
+type cachingTransport struct {
+	kind string
+	base http.RoundTripper
+}
+
+func cacheTransport(kind string) http.RoundTripper {
+	return &cachingTransport{kind: kind, base: http.DefaultTransport}
+}
+
+// cachedClient: http.Client mit Cache-Transport.
+func cachedClient(kind string, timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout, Transport: cacheTransport(kind)}
+}
+
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || !cacheEnabled() {
+		if runCfg.Offline {
+			return nil, errOfflineMiss
+		}
+		return t.base.RoundTrip(req)
+	}
+	key := req.URL.String()
+	if e, ok := cacheGet(t.kind, key); ok {
+		return cachedResponse(req, e), nil
+	}
+	if runCfg.Offline {
+		return nil, fmt.Errorf("%w: %s", errOfflineMiss, key)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	cacheable := resp.StatusCode == http.StatusOK ||
+		(resp.StatusCode >= 300 && resp.StatusCode < 400 && resp.Header.Get("Location") != "")
+	if !cacheable || resp.ContentLength > maxCacheBodyBytes {
+		return resp, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCacheBodyBytes+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > maxCacheBodyBytes {
+		// zu groß für den Cache (ohne Content-Length): gelesenen Anfang + Rest durchreichen
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+	cachePut(t.kind, key, resp.StatusCode, resp.Header.Get("Content-Type"), resp.Header.Get("Location"), body)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	return resp, nil
+}
+
+func cachedResponse(req *http.Request, e *cacheEntry) *http.Response {
+	h := http.Header{}
+	if e.Meta.ContentType != "" {
+		h.Set("Content-Type", e.Meta.ContentType)
+	}
+	if e.Meta.Location != "" {
+		h.Set("Location", e.Meta.Location)
+	}
+	h.Set("Content-Length", strconv.Itoa(len(e.Body)))
+	h.Set("X-From-Cache", "1")
+	return &http.Response{
+		Status:        strconv.Itoa(e.Meta.Status) + " " + http.StatusText(e.Meta.Status),
+		StatusCode:    e.Meta.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        h,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// politeSleep: Höflichkeitspausen entfallen im Offline-Modus.
+func politeSleep(d time.Duration) {
+	if runCfg.Offline {
+		return
+	}
+	time.Sleep(d)
+}
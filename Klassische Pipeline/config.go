@@ -0,0 +1,196 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// -------------------- Laufzeit-Konfiguration --------------------
+
+// runConfig bündelt alle per CLI einstellbaren Parameter eines Laufs.
+type runConfig struct {
+	InputFile string
+
+	CrawlDepth    int // Link-Tiefe ab Trefferseite (0 = kein Crawl)
+	CrawlMaxPages int // max. Zusatzseiten je Trefferseite
+
+	UseSitemaps bool // Profilseiten über robots.txt/sitemap.xml der Institution suchen
+
+	RespectRobots bool // robots.txt (Disallow, Crawl-delay) für Colly/Chromedp/PDF beachten
+
+	BrowserTabs   int      // max. gleichzeitige Tabs im gemeinsamen Headless-Browser
+	InteractSteps []string // Browser-Schritte vor der Extraktion (siehe interact.go)
+
+	BlockResources []string // im Browser blockierte Ressourcentypen (siehe resblock.go)
+	BlockTrackers  bool     // bekannte Tracker-/Werbe-Hosts blockieren
+
+	EvidenceDir string // Screenshot + DOM je akzeptiertem Treffer ("" = aus)
+
+	CorpusDir string // lokales Verzeichnis statt Suche auswerten ("" = aus; siehe corpus.go)
+
+	DocTypes      []string // ausgewertete Dokumenttypen (siehe docextract.go)
+	SniffDocLinks bool     // Download-Links ohne Endung per HEAD/Range-GET prüfen (docsniff.go)
+	DocStore      bool     // Dokumente per SHA-256 ablegen und für weitere Personen nutzen (docstore.go)
+
+	PDFWorkers       int // gleichzeitige PDF-Worker-Prozesse (siehe pdfworker.go)
+	PDFWorkerMaxJobs int // Worker nach so vielen PDFs ersetzen
+	PDFWorkerMemMB   int // Speicherlimit je Worker (GOMEMLIMIT; darüber → ersetzen)
+
+	PDFWorkerASMB    int           // hartes Adressraum-Limit je Worker (Linux, 0 = aus; siehe pdfsandbox.go)
+	PDFWorkerCPU     time.Duration // CPU-Zeit je PDF (Linux-rlimit, 0 = aus)
+	PDFWorkerNoFile  int           // max. offene Dateien je Worker (Linux, 0 = aus)
+	PDFWorkerSeccomp bool          // seccomp-Filter im Worker (Linux)
+
+	CacheEnabled bool          // HTTP-Antworten auf Platte cachen
+	CacheDir     string        // Cache-Verzeichnis
+	CacheTTL     time.Duration // Gültigkeit eines Eintrags (0 = unbegrenzt)
+	CacheMaxMB   int           // Größenlimit (älteste Einträge fliegen zuerst)
+	Offline      bool          // nur aus dem Cache lesen, kein Netzwerk
+}
+
+func defaultRunConfig() runConfig {
+	return runConfig{
+		InputFile:     "list_of_names_and_affiliations.csv",
+		CrawlDepth:    1,
+		CrawlMaxPages: 4,
+		UseSitemaps:   true,
+		RespectRobots: true,
+		BrowserTabs:   2,
+		InteractSteps: interactionSteps,
+
+		BlockResources: []string{"image", "media", "font"},
+		BlockTrackers:  true,
+
+		DocTypes:      docTypeNames(),
+		SniffDocLinks: true,
+		DocStore:      true,
+
+		PDFWorkers:       2,
+		PDFWorkerMaxJobs: 50,
+		PDFWorkerMemMB:   200,
+		PDFWorkerASMB:    4096,
+		PDFWorkerCPU:     10 * time.Second,
+		PDFWorkerNoFile:  64,
+
+		CacheEnabled: true,
+		CacheDir:     filepath.Join(".cache", "http"),
+		CacheTTL:     7 * 24 * time.Hour,
+		CacheMaxMB:   1024,
+	}
+}
+
+// runCfg ist die aktive Konfiguration (nach parseRunFlags).
+var runCfg = defaultRunConfig()
+
+// parseRunFlags liest Flags und die optionale Eingabedatei (erstes freies Argument).
+func parseRunFlags(args []string) error {
+	cfg := defaultRunConfig()
+	fs := flag.NewFlagSet("pipeline", flag.ContinueOnError)
+	fs.IntVar(&cfg.CrawlDepth, "crawl-depth", cfg.CrawlDepth, "Link-Tiefe für Kontakt-/Profilseiten ab Trefferseite (0 = aus)")
+	fs.IntVar(&cfg.CrawlMaxPages, "crawl-pages", cfg.CrawlMaxPages, "max. Zusatzseiten je Trefferseite")
+	fs.BoolVar(&cfg.UseSitemaps, "sitemaps", cfg.UseSitemaps, "Sitemaps der Institutions-Domain vor den DDG-Treffern auswerten")
+	fs.BoolVar(&cfg.RespectRobots, "robots", cfg.RespectRobots, "robots.txt beachten (--robots=false schaltet ab)")
+	fs.IntVar(&cfg.BrowserTabs, "browser-tabs", cfg.BrowserTabs, "max. gleichzeitige Tabs im Headless-Browser")
+	interact := fs.String("interact", strings.Join(cfg.InteractSteps, ","), "Browser-Schritte vor der Extraktion: consent,reveal,scroll,idle (none = aus)")
+	block := fs.String("block", strings.Join(cfg.BlockResources, ","), "im Browser blockierte Ressourcen: image,media,font,stylesheet (none = aus)")
+	fs.BoolVar(&cfg.BlockTrackers, "block-trackers", cfg.BlockTrackers, "Tracker-/Werbe-Hosts im Browser blockieren")
+	fs.StringVar(&cfg.EvidenceDir, "evidence-dir", cfg.EvidenceDir, "Belege (Screenshot, DOM) akzeptierter Treffer in diesem Verzeichnis ablegen")
+	fs.StringVar(&cfg.CorpusDir, "corpus", cfg.CorpusDir, "lokales Verzeichnis mit PDFs/Dokumenten/HTML statt Websuche auswerten (ohne Netzwerk)")
+	docTypes := fs.String("doc-types", strings.Join(cfg.DocTypes, ","), "ausgewertete Dokumenttypen: "+strings.Join(docTypeNames(), ",")+" (none = keine)")
+	fs.BoolVar(&cfg.SniffDocLinks, "sniff-docs", cfg.SniffDocLinks, "Dokument-Links ohne .pdf-Endung per HEAD/Range-GET erkennen")
+	fs.BoolVar(&cfg.DocStore, "doc-store", cfg.DocStore, "gescannte Dokumente per SHA-256 merken und für Co-Autoren wiederverwenden")
+	fs.IntVar(&cfg.PDFWorkers, "pdf-workers", cfg.PDFWorkers, "gleichzeitige PDF-Worker-Prozesse")
+	fs.IntVar(&cfg.PDFWorkerMaxJobs, "pdf-worker-jobs", cfg.PDFWorkerMaxJobs, "PDF-Worker nach so vielen Dokumenten neu starten")
+	fs.IntVar(&cfg.PDFWorkerMemMB, "pdf-worker-mem-mb", cfg.PDFWorkerMemMB, "Speicherlimit je PDF-Worker in MiB")
+	fs.IntVar(&cfg.PDFWorkerASMB, "pdf-worker-as-mb", cfg.PDFWorkerASMB, "hartes Adressraum-Limit je PDF-Worker in MiB (Linux, 0 = aus)")
+	fs.DurationVar(&cfg.PDFWorkerCPU, "pdf-worker-cpu", cfg.PDFWorkerCPU, "CPU-Zeit je PDF (Linux, 0 = aus)")
+	fs.IntVar(&cfg.PDFWorkerNoFile, "pdf-worker-nofile", cfg.PDFWorkerNoFile, "max. offene Dateien je PDF-Worker (Linux, 0 = aus)")
+	fs.BoolVar(&cfg.PDFWorkerSeccomp, "pdf-worker-seccomp", cfg.PDFWorkerSeccomp, "PDF-Worker mit seccomp-Filter starten (Linux: kein Netzwerk, kein exec)")
+	fs.BoolVar(&cfg.CacheEnabled, "cache", cfg.CacheEnabled, "HTTP-Antworten auf Platte cachen")
+	fs.StringVar(&cfg.CacheDir, "cache-dir", cfg.CacheDir, "Verzeichnis für den HTTP-Cache")
+	fs.DurationVar(&cfg.CacheTTL, "cache-ttl", cfg.CacheTTL, "Gültigkeit eines Cache-Eintrags (0 = unbegrenzt)")
+	fs.IntVar(&cfg.CacheMaxMB, "cache-max-mb", cfg.CacheMaxMB, "Größenlimit des Caches in MiB")
+	fs.BoolVar(&cfg.Offline, "offline", cfg.Offline, "nur aus dem Cache lesen (kein Netzwerk)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	steps, err := parseListOption(*interact, interactionSteps)
+	if err != nil {
+		return fmt.Errorf("-interact: %w", err)
+	}
+	cfg.InteractSteps = steps
+	var blockable []string
+	for name := range blockableResources {
+		blockable = append(blockable, name)
+	}
+	sort.Strings(blockable)
+	if cfg.BlockResources, err = parseListOption(*block, blockable); err != nil {
+		return fmt.Errorf("-block: %w", err)
+	}
+	if cfg.DocTypes, err = parseListOption(*docTypes, docTypeNames()); err != nil {
+		return fmt.Errorf("-doc-types: %w", err)
+	}
+	if cfg.CorpusDir != "" {
+		// Korpus-Modus: nie ins Netz (vCard-Links, robots.txt) – nur Dateien und Cache
+		cfg.Offline = true
+		cfg.RespectRobots = false
+	}
+	if fs.NArg() > 0 && strings.TrimSpace(fs.Arg(0)) != "" {
+		cfg.InputFile = fs.Arg(0)
+	}
+	if cfg.CrawlDepth < 0 {
+		cfg.CrawlDepth = 0
+	}
+	if cfg.CrawlMaxPages < 0 {
+		cfg.CrawlMaxPages = 0
+	}
+	if cfg.BrowserTabs < 1 {
+		cfg.BrowserTabs = 1
+	}
+	if cfg.PDFWorkers < 1 {
+		cfg.PDFWorkers = 1
+	}
+	if cfg.PDFWorkerMaxJobs < 1 {
+		cfg.PDFWorkerMaxJobs = 1
+	}
+	if cfg.PDFWorkerMemMB < 32 {
+		cfg.PDFWorkerMemMB = 32
+	}
+	// Go reserviert beim Start ~1,5 GiB Adressraum, der Heap kommt oben drauf
+	if minAS := 2048 + cfg.PDFWorkerMemMB; cfg.PDFWorkerASMB > 0 && cfg.PDFWorkerASMB < minAS {
+		return fmt.Errorf("-pdf-worker-as-mb: mindestens %d (Go-Runtime + Speicherlimit)", minAS)
+	}
+	runCfg = cfg
+	return nil
+}
+
+// parseListOption: „a,b“ → Liste (nur erlaubte Werte); „none“/leer → keine.
+func parseListOption(s string, allowed []string) ([]string, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	if s == "" || s == "none" {
+		return nil, nil
+	}
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		known := false
+		for _, k := range allowed {
+			if part == k {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unbekannter Wert %q (erlaubt: %s)", part, strings.Join(allowed, ","))
+		}
+		out = append(out, part)
+	}
+	return out, nil
+}
@@ -0,0 +1,350 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// =================== Lokaler Korpus (offline) ===================
+//
+// Mit -corpus DIR entfallen Suche und Abruf: Das Verzeichnis wird rekursiv
+// nach PDFs, Office-Dokumenten und gespeicherten HTML-Seiten durchsucht und
+// einmal indiziert, danach bekommt jede Person der CSV die am besten
+// bewertete Adresse.
+//   - Dokumente: einmal im PDF-Worker vollständig gescannt (derselbe Scanner
+//     wie ExtractEmailsFromPDFCtx, aber alle Seiten und ohne Kandidatenlimit,
+//     damit auch innere Beiträge eines Tagungsbands zählen). Alle Adressen
+//     landen im Dokument-Store und werden je Person neu bewertet
+//     (docstore.go). Ohne die Person als Autor zählen nur namensnahe
+//     Adressen. Endete der Scan vorzeitig und steht die Person als Autor im
+//     Dokument, folgt ein gezielter Scan für sie.
+//   - HTML: dieselben Extraktoren wie Colly (Text, HTML, strukturierte Daten,
+//     mailto:), bewertet nur auf Seiten, die den Nachnamen enthalten.
+// Der Modus erzwingt --offline; das Netzwerk wird nie angefasst.
+
+const (
+	corpusMaxDocBytes  = 64 << 20 // größere Dateien werden übersprungen
+	corpusMaxHTMLBytes = 4 << 20
+	corpusRescanMax    = 4               // gezielte Scans je Person (unvollständige Dokumente mit der Person als Autor)
+	corpusDocTimeout   = 3 * time.Minute // Indizierung liest alle Seiten
+
+	corpusKindHTML = "html"
+)
+
+var corpusHTMLExts = []string{".html", ".htm", ".xhtml", ".shtml"}
+
+// corpusPage: eine lokal gespeicherte HTML-Seite.
+type corpusPage struct {
+	Path    string
+	Text    string // sichtbarer Text des <body>
+	HTML    string // HTML des <body>
+	Folded  string // Text klein + ASCII-gefaltet (Namensfilter)
+	Persons []structuredPerson
+	Mailtos []string
+
+	byOrg map[string][]string // Rohkandidaten je Organisation (symbolische Erkennung hängt davon ab)
+}
+
+// corpusIndex: Ergebnis der Indizierung.
+type corpusIndex struct {
+	mu      sync.Mutex
+	Docs    []string          // SHA-256 der Dokumente (Records im Dokument-Store)
+	Paths   map[string]string // SHA-256 → lokaler Pfad (Records können URLs früherer Web-Läufe tragen)
+	Pages   []*corpusPage
+	Skipped int
+}
+
+// runCorpusMode ersetzt die Such-Pipeline (main.go) für ein lokales Verzeichnis.
+func runCorpusMode(entries []PersonEntry) {
+	startAll := time.Now()
+	idx, err := buildCorpusIndex(runCfg.CorpusDir)
+	if err != nil {
+		fmt.Printf("Fehler beim Lesen des Korpus (%s): %v\n", runCfg.CorpusDir, err)
+		return
+	}
+	fmt.Printf("📚 Korpus %s: %d Dokumente, %d HTML-Seiten, %d übersprungen (%.2fs)\n",
+		runCfg.CorpusDir, len(idx.Docs), len(idx.Pages), idx.Skipped, time.Since(startAll).Seconds())
+
+	results := make([]ResultRow, 0, len(entries))
+	foundCount := 0
+	for i, entry := range entries {
+		query := buildQuery(entry)
+		if query == "" {
+			continue
+		}
+		fmt.Printf("\n➡️ [%d/%d] Korpus: %s\n", i+1, len(entries), query)
+
+		row := ResultRow{Name: query}
+		row.Email, row.Source, row.Mode, row.Via = idx.bestFor(entry, query)
+		addResultOnce(&results, row)
+		if row.Email != "" {
+			foundCount++
+			fmt.Printf("✅ Found (%s): %s => %s (%s)\n", row.Mode, query, row.Email, row.Source)
+		} else {
+			fmt.Printf("❌ Keine passende E-Mail gefunden für: %s\n", query)
+		}
+	}
+	writeResults(results, foundCount, len(entries), startAll)
+}
+
+// -------------------- Indizierung --------------------
+
+func buildCorpusIndex(root string) (*corpusIndex, error) {
+	if st, err := os.Stat(root); err != nil {
+		return nil, err
+	} else if !st.IsDir() {
+		return nil, fmt.Errorf("%s ist kein Verzeichnis", root)
+	}
+
+	idx := &corpusIndex{Paths: map[string]string{}}
+	var docs []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // unlesbare Einträge überspringen
+		}
+		if p != root && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		switch corpusFileKind(p) {
+		case "":
+		case corpusKindHTML:
+			if pg := readCorpusPage(p); pg != nil {
+				idx.Pages = append(idx.Pages, pg)
+			} else {
+				idx.Skipped++
+			}
+		default:
+			docs = append(docs, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Dokumente parallel über den Worker-Pool (gleicher Inhalt nur einmal)
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	for w := 0; w < maxInt(1, runCfg.PDFWorkers); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range jobs {
+				idx.addDocument(p)
+			}
+		}()
+	}
+	for _, p := range docs {
+		jobs <- p
+	}
+	close(jobs)
+	wg.Wait()
+	sort.Strings(idx.Docs)
+	return idx, nil
+}
+
+// corpusFileKind: corpusKindHTML oder der Dokumenttyp ("" = ignorieren).
+// Dateien ohne Endung werden an den Magic Bytes erkannt.
+func corpusFileKind(p string) string {
+	ext := strings.ToLower(filepath.Ext(p))
+	for _, x := range corpusHTMLExts {
+		if ext == x {
+			return corpusKindHTML
+		}
+	}
+	typ := docTypeByURL(p)
+	if typ == "" && ext == "" {
+		typ = detectDocType(p, "")
+	}
+	if typ == "" || !docTypeEnabled(typ) {
+		return ""
+	}
+	return typ
+}
+
+// addDocument scannt ein Dokument (ohne Person) und legt den Harvest im Dokument-Store ab.
+func (idx *corpusIndex) addDocument(p string) {
+	st, err := os.Stat(p)
+	if err != nil || st.Size() > corpusMaxDocBytes {
+		idx.skip()
+		return
+	}
+	sha, err := fileSHA256(p)
+	if err != nil {
+		idx.skip()
+		return
+	}
+	if rec := docStoreBySHA(sha); rec != nil && rec.Complete {
+		docStorePut(sha, p, rec.Type, docHarvest{}) // Duplikat bzw. aus früherem Lauf
+		idx.addSHA(sha, p)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), corpusDocTimeout)
+	typ, res, err := pdfWorkers().scanWithCPU(ctx, p, "", "", corpusDocTimeout)
+	cancel()
+	// Frist- oder Limitende: der Teil-Harvest bleibt (unvollständig) erhalten
+	if err != nil && len(res.Harvest.Addresses) == 0 {
+		fmt.Printf("⏭️ [Korpus] %s: %v\n", p, err)
+		idx.skip()
+		return
+	}
+	docStorePut(sha, p, typ, res.Harvest)
+	idx.addSHA(sha, p)
+}
+
+// addSHA nimmt ein Dokument auf; bei gleichem Inhalt bleibt der erste Pfad.
+func (idx *corpusIndex) addSHA(sha, p string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if _, ok := idx.Paths[sha]; ok {
+		return
+	}
+	idx.Paths[sha] = p
+	idx.Docs = append(idx.Docs, sha)
+}
+
+func (idx *corpusIndex) skip() {
+	idx.mu.Lock()
+	idx.Skipped++
+	idx.mu.Unlock()
+}
+
+// readCorpusPage liest eine gespeicherte Seite wie Colly den <body> sieht (nil = unlesbar).
+func readCorpusPage(p string) *corpusPage {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	doc, err := goquery.NewDocumentFromReader(io.LimitReader(f, corpusMaxHTMLBytes))
+	if err != nil {
+		return nil
+	}
+	pg := &corpusPage{Path: p, byOrg: map[string][]string{}}
+	body := doc.Find("body")
+	pg.Text = body.Text()
+	pg.HTML, _ = body.Html()
+	// Namensfilter über die einzelnen Textknoten: body.Text() verklebt Blöcke
+	// („<h1>Klara Weber</h1><p>Universität</p>“ → „weberuniversität“)
+	var texts []string
+	body.Find("*").AddBack().Each(func(_ int, s *goquery.Selection) {
+		texts = append(texts, s.Contents().Not("*").Text())
+	})
+	pg.Folded = asciiFold(strings.ToLower(strings.Join(texts, " ")))
+	pg.Persons = extractStructuredPersons(doc.Selection)
+	doc.Find("a[href^='mailto:']").Each(func(_ int, a *goquery.Selection) {
+		href := strings.TrimPrefix(a.AttrOr("href", ""), "mailto:")
+		for _, src := range []string{href, a.Text()} {
+			if mail := extractEmailFromText(src); mail != "" {
+				pg.Mailtos = append(pg.Mailtos, mail)
+			}
+		}
+	})
+	return pg
+}
+
+// candidates: Rohkandidaten der Seite für eine Organisation (gecacht).
+func (pg *corpusPage) candidates(org string) []string {
+	if c, ok := pg.byOrg[org]; ok {
+		return c
+	}
+	c := append(htmlEmailCandidates(pg.Text, pg.HTML, org), pg.Mailtos...)
+	pg.byOrg[org] = c
+	return c
+}
+
+// -------------------- Zuordnung --------------------
+
+// bestFor bewertet alle Dokumente und passenden Seiten für eine Person und liefert
+// die beste Adresse samt Quelle (Pfad) und Modus.
+func (idx *corpusIndex) bestFor(entry PersonEntry, query string) (email, source, mode, via string) {
+	candidates := map[string]*candInfo{}
+	register := func(mail string, score int, src, mode, via string) {
+		if mail == "" || score <= 0 {
+			return
+		}
+		registerCandidate(candidates, mail, score, src, mode, via)
+	}
+
+	// Dokumente: gespeicherte Adressen neu bewerten
+	first, last := splitPersonName(entry.Name)
+	rescans := 0
+	for _, sha := range idx.Docs {
+		rec := docStoreBySHA(sha)
+		if rec == nil {
+			continue
+		}
+		src, recMode := idx.Paths[sha], docRecordMode(rec.Type)
+		res := rec.rescore(query)
+		if !rec.Complete && res.Score < highConfidenceCutoff && rescans < corpusRescanMax && rec.hasAuthor(first, last) {
+			// unvollständig gescannt, Person ist Autor → gezielt für die Person scannen
+			rescans++
+			ctx, cancel := context.WithTimeout(context.Background(), pdfJobTimeout)
+			typ, direct, err := pdfWorkers().scan(ctx, src, "", query)
+			cancel()
+			if err == nil || len(direct.Candidates) > 0 {
+				docStorePut(sha, src, typ, direct.Harvest)
+				res = direct
+			}
+		}
+		// Dokumente ohne Bezug zur Person zählen nur mit namensnahen Adressen
+		author := rec.hasAuthor(first, last)
+		for _, c := range res.Candidates {
+			if author || c.Score >= consensusMinScore {
+				register(c.Email, c.Score, src, recMode, c.Via)
+			}
+		}
+	}
+
+	// HTML-Seiten: wie Colly, aber nur Seiten mit dem Nachnamen
+	if last != "" {
+		f, m, l, org := splitNameAndOrg(cleanQueryNoise(query))
+		for _, pg := range idx.Pages {
+			if !containsWord(pg.Folded, last) {
+				continue
+			}
+			for _, p := range pg.Persons {
+				if mail, score := scoreStructuredPerson(p, f, m, l, org); mail != "" {
+					register(mail, score, pg.Path, fetchModeLocal, "")
+				}
+			}
+			for _, raw := range pg.candidates(org) {
+				if mail := extractEmailFromText(raw); mail != "" {
+					register(mail, getScoreOrgGeneral(strings.ToLower(mail), f, m, l, org), pg.Path, fetchModeLocal, "")
+				}
+			}
+		}
+	}
+
+	// höchster Score; bei Gleichstand mehr Quellen, dann alphabetisch (deterministisch)
+	bestScore, bestSources := 0, 0
+	for mail, info := range candidates {
+		n := len(info.sources)
+		if info.bestScore > bestScore ||
+			(info.bestScore == bestScore && (n > bestSources || (n == bestSources && mail < email))) {
+			email, bestScore, bestSources = mail, info.bestScore, n
+		}
+	}
+	if email == "" {
+		return "", "", "", ""
+	}
+	info := candidates[email]
+	return email, info.bestSource, info.bestMode, info.bestVia
+}
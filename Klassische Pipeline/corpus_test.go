@@ -0,0 +1,114 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func mustWriteFile(t *testing.T, p string, data []byte) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// netGuard: jeder HTTP-Abruf über den Standard-Client ist ein Testfehler.
+type netGuard struct{ calls []string }
+
+func (g *netGuard) RoundTrip(req *http.Request) (*http.Response, error) {
+	g.calls = append(g.calls, req.URL.String())
+	return nil, errors.New("netGuard: kein Netzwerk im Korpus-Modus")
+}
+
+func TestCorpusFileKind(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"team.html", "<html></html>", corpusKindHTML},
+		{"Profil.XHTML", "<html></html>", corpusKindHTML},
+		{"paper.pdf", "%PDF-1.4", docTypePDF},
+		{"cv.docx", "PK", docTypeDOCX},
+		{"download", "%PDF-1.7\n", docTypePDF}, // ohne Endung: Magic Bytes
+		{"README", "nur Text", ""},
+		{"portrait.png", "\x89PNG", ""},
+		{"notes.txt", "jane.doe@uni-x.de", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := writeTemp(t, tt.name, []byte(tt.data))
+			if got := corpusFileKind(p); got != tt.want {
+				t.Errorf("corpusFileKind(%s) = %q, erwartet %q", filepath.Base(p), got, tt.want)
+			}
+		})
+	}
+}
+
+// Ein Verzeichnis mit einer HTML-Seite und einem Dokument, dessen Harvest schon im Store liegt:
+// Indizierung und Zuordnung laufen ohne Netzwerk und ohne PDF-Worker.
+func TestCorpusBestFor(t *testing.T) {
+	testDocStore(t)
+	runCfg.Offline = true
+	guard := &netGuard{}
+	defer func(rt http.RoundTripper) { http.DefaultTransport = rt }(http.DefaultTransport)
+	http.DefaultTransport = guard
+
+	dir := t.TempDir()
+	pdfData := []byte("%PDF-1.4\n% Korpus-Testdokument\n")
+	pdfPath := filepath.Join(dir, "paper.pdf")
+	htmlPath := filepath.Join(dir, "team", "weber.html")
+	for p, data := range map[string][]byte{
+		pdfPath:  pdfData,
+		htmlPath: []byte(`<html><body><h1>Dr. Klara Weber</h1><p>Universität Y</p><a href="mailto:klara.weber@uni-y.de">E-Mail</a></body></html>`),
+		filepath.Join(dir, ".hidden", "x.html"): []byte(`<html><body>Klara Weber <a href="mailto:falsch@spam.org">x</a></body></html>`),
+	} {
+		mustWriteFile(t, p, data)
+	}
+	sha, err := fileSHA256(pdfPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	docStorePut(sha, "https://uni-x.de/paper.pdf", docTypePDF, testPaperHarvest(true))
+
+	idx, err := buildCorpusIndex(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(idx.Docs) != 1 || idx.Paths[sha] != pdfPath || len(idx.Pages) != 1 {
+		t.Fatalf("Index: %d Dokumente, Pfad %q, %d Seiten", len(idx.Docs), idx.Paths[sha], len(idx.Pages))
+	}
+
+	tests := []struct {
+		entry  PersonEntry
+		email  string
+		source string
+		mode   string
+	}{
+		{PersonEntry{Name: "John Smith", Institution: "University Y"}, "john.smith@uni-y.de", pdfPath, fetchModePDF},
+		{PersonEntry{Name: "Jane Doe"}, "jane.doe@uni-x.de", pdfPath, fetchModePDF},
+		{PersonEntry{Name: "Klara Weber", Institution: "Universität Y"}, "klara.weber@uni-y.de", htmlPath, fetchModeLocal},
+		{PersonEntry{Name: "Max Muster"}, "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.entry.Name, func(t *testing.T) {
+			email, source, mode, _ := idx.bestFor(tt.entry, buildQuery(tt.entry))
+			if email != tt.email || source != tt.source || mode != tt.mode {
+				t.Errorf("bestFor = %q, %q, %q; erwartet %q, %q, %q", email, source, mode, tt.email, tt.source, tt.mode)
+			}
+		})
+	}
+
+	if len(guard.calls) > 0 {
+		t.Errorf("Netzwerkzugriffe: %v", guard.calls)
+	}
+	if p := pdfPool.pool; p != nil && p.stats.started > 0 {
+		t.Errorf("%d PDF-Worker gestartet, erwartet keinen", p.stats.started)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// =================== Fokussierter Crawl (1 Hop) ===================
+//
+// Ist ein Treffer nur die Lab-Startseite oder ein Instituts-Index, folgen wir
+// innerhalb eines Budgets Links derselben Site, deren Ankertext oder Pfad
+// nach Kontakt-/Profilseite aussieht.
+
+// Schlüsselwörter in Ankertext/Pfad → Priorität (höher = früher besuchen).
+var profileLinkKeywords = map[string]int{
+	"contact": 3, "kontakt": 3, "impressum": 2, "imprint": 2,
+	"people": 2, "person": 2, "personen": 2, "team": 2, "staff": 2, "mitarbeiter": 2,
+	"members": 2, "faculty": 2, "directory": 1, "profile": 2, "profil": 2, "about": 1,
+	"homepage": 1, "~": 1,
+}
+
+type crawlLink struct {
+	URL  string
+	Prio int
+}
+
+// profileLinkPriority bewertet einen Link; 0 = nicht folgen.
+// Links mit dem Namen der Person (Text oder Pfad, z. B. „~lastname“) zählen am meisten.
+func profileLinkPriority(anchorText, link, pageURL, first, last string) int {
+	if !sameSite(link, pageURL) {
+		return 0
+	}
+	u, err := url.Parse(link)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return 0
+	}
+	lp := strings.ToLower(u.Path)
+	for _, ext := range []string{".pdf", ".jpg", ".jpeg", ".png", ".gif", ".zip", ".doc", ".docx", ".ppt", ".pptx", ".mp4", ".css", ".js"} {
+		if strings.HasSuffix(lp, ext) {
+			return 0
+		}
+	}
+	text := asciiFold(strings.ToLower(strings.Join(strings.Fields(anchorText), " ")))
+	path := asciiFold(lp)
+
+	prio := 0
+	if l := asciiFold(strings.ToLower(last)); len(l) >= 3 {
+		if strings.Contains(path, "~"+l) {
+			prio += 6
+		} else if strings.Contains(path, l) || strings.Contains(text, l) {
+			prio += 5
+		}
+		if f := asciiFold(strings.ToLower(first)); len(f) >= 2 && (strings.Contains(text, f) || strings.Contains(path, f)) {
+			prio += 1
+		}
+	}
+	for kw, p := range profileLinkKeywords {
+		if strings.Contains(text, kw) || strings.Contains(path, kw) {
+			prio = maxInt(prio, p)
+		}
+	}
+	return prio
+}
+
+// sameSite: gleiche registrierbare Domain (eTLD+1), z. B. cs.bu.edu ~ www.bu.edu.
+func sameSite(a, b string) bool {
+	ua, err1 := url.Parse(a)
+	ub, err2 := url.Parse(b)
+	if err1 != nil || err2 != nil || ua.Hostname() == "" || ub.Hostname() == "" {
+		return false
+	}
+	ra, err1 := publicsuffix.EffectiveTLDPlusOne(strings.ToLower(ua.Hostname()))
+	rb, err2 := publicsuffix.EffectiveTLDPlusOne(strings.ToLower(ub.Hostname()))
+	if err1 != nil || err2 != nil {
+		return strings.EqualFold(ua.Hostname(), ub.Hostname())
+	}
+	return ra == rb
+}
+
+// nextCrawlFrontier dedupliziert und sortiert die gesammelten Links nach Priorität.
+func nextCrawlFrontier(links []crawlLink, visited map[string]bool) []crawlLink {
+	best := map[string]int{}
+	for _, l := range links {
+		key := strings.TrimSuffix(strings.SplitN(l.URL, "#", 2)[0], "/")
+		if visited[key] {
+			continue
+		}
+		if l.Prio > best[key] {
+			best[key] = l.Prio
+		}
+	}
+	out := make([]crawlLink, 0, len(best))
+	for u, p := range best {
+		out = append(out, crawlLink{URL: u, Prio: p})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Prio != out[j].Prio {
+			return out[i].Prio > out[j].Prio
+		}
+		return out[i].URL < out[j].URL
+	})
+	return out
+}
@@ -94,6 +94,11 @@ func WriteCSV(outputFile string, results []ResultRow) error {
 	defer w.Flush()
 
 	for _, row := range results {
+		// Beleg-Spalte nur, wenn Belege gesammelt werden (Format sonst unverändert)
+		if runCfg.EvidenceDir != "" {
+			_ = w.Write([]string{row.Name, row.Email, row.Evidence})
+			continue
+		}
 		_ = w.Write([]string{row.Name, row.Email})
 	}
 	return nil
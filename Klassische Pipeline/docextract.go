@@ -0,0 +1,777 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =================== Weitere Dokumenttypen ===================
+//
+// Lebensläufe, Mitarbeiterlisten und Abschlussarbeiten liegen oft als
+// DOCX, ODT, PPTX oder RTF vor. Die Registry erkennt den Typ an den Magic
+// Bytes (bei ZIP-Containern am Inhalt), ersatzweise am Content-Type, und
+// zieht den Text in reinem Go heraus. Danach läuft derselbe docScorer wie
+// für PDF-Seiten (Metadaten-Autor, Autorenblock, Reparaturen, Scoring).
+
+const (
+	docTypePDF  = "pdf"
+	docTypeDOCX = "docx"
+	docTypePPTX = "pptx"
+	docTypeODT  = "odt"
+	docTypeODP  = "odp"
+	docTypeRTF  = "rtf"
+	docTypePS   = "ps"
+
+	docSniffBytes    = 1024     // Kopf für die Magic-Bytes
+	maxDocEntryBytes = 8 << 20  // max. entpackte Bytes je ZIP-Eintrag
+	maxDocZipBytes   = 24 << 20 // max. entpackte Bytes je Dokument (ZIP-Bomben)
+	maxDocEntries    = 400      // max. ausgewertete Einträge (Folien, Kopfzeilen …)
+)
+
+// docText: Text und Metadaten eines Nicht-PDF-Dokuments.
+type docText struct {
+	Text string
+	Meta pdfMeta // Autoren, mailto:-Links, Adressen aus Eigenschaften
+}
+
+// docExtractor beschreibt einen Dokumenttyp.
+type docExtractor struct {
+	Type    string
+	Exts    []string                           // URL-Endungen (Suche, Download)
+	Mimes   []string                           // Content-Types
+	Magic   func(head []byte) bool             // nil = nur über ZIP-Inhalt/Content-Type
+	Extract func(path string) (docText, error) // nil = eigener Scanner (PDF)
+}
+
+var docExtractors = []docExtractor{
+	{
+		Type:  docTypePDF,
+		Exts:  []string{".pdf"},
+		Mimes: []string{"application/pdf", "application/x-pdf"},
+		Magic: func(h []byte) bool { return bytes.Contains(h, []byte("%PDF-")) },
+	},
+	{
+		Type:    docTypeDOCX,
+		Exts:    []string{".docx"},
+		Mimes:   []string{"application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+		Extract: extractOOXML,
+	},
+	{
+		Type:    docTypePPTX,
+		Exts:    []string{".pptx"},
+		Mimes:   []string{"application/vnd.openxmlformats-officedocument.presentationml.presentation"},
+		Extract: extractOOXML,
+	},
+	{
+		Type:    docTypeODT,
+		Exts:    []string{".odt"},
+		Mimes:   []string{"application/vnd.oasis.opendocument.text"},
+		Magic:   func(h []byte) bool { return odfMagic(h, "application/vnd.oasis.opendocument.text") },
+		Extract: extractODF,
+	},
+	{
+		Type:    docTypeODP,
+		Exts:    []string{".odp"},
+		Mimes:   []string{"application/vnd.oasis.opendocument.presentation"},
+		Magic:   func(h []byte) bool { return odfMagic(h, "application/vnd.oasis.opendocument.presentation") },
+		Extract: extractODF,
+	},
+	{
+		Type:    docTypeRTF,
+		Exts:    []string{".rtf"},
+		Mimes:   []string{"application/rtf", "text/rtf", "application/x-rtf"},
+		Magic:   func(h []byte) bool { return bytes.HasPrefix(bytes.TrimLeft(h, " \t\r\n"), []byte(`{\rtf`)) },
+		Extract: extractRTF,
+	},
+	{
+		Type:    docTypePS,
+		Exts:    []string{".ps"},
+		Mimes:   []string{"application/postscript"},
+		Magic:   func(h []byte) bool { return bytes.HasPrefix(h, []byte("%!PS")) },
+		Extract: extractPostScript,
+	},
+}
+
+// docTypeNames: alle registrierten Typen (für -doc-types).
+func docTypeNames() []string {
+	out := make([]string, 0, len(docExtractors))
+	for _, e := range docExtractors {
+		out = append(out, e.Type)
+	}
+	return out
+}
+
+func docExtractorFor(typ string) *docExtractor {
+	for i := range docExtractors {
+		if docExtractors[i].Type == typ {
+			return &docExtractors[i]
+		}
+	}
+	return nil
+}
+
+// docTypeEnabled: Typ ist per -doc-types eingeschaltet.
+func docTypeEnabled(typ string) bool {
+	for _, t := range runCfg.DocTypes {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// docSearchQuery: Zusatzsuche nach den übrigen aktiven Typen ("" = nur PDF aktiv).
+func docSearchQuery(query string) string {
+	var parts []string
+	for _, t := range runCfg.DocTypes {
+		if e := docExtractorFor(t); e != nil && t != docTypePDF {
+			parts = append(parts, "filetype:"+strings.TrimPrefix(e.Exts[0], "."))
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return query + " " + strings.Join(parts, " OR ")
+}
+
+// docTypeByURL: Typ anhand der Endung im Pfad ("" = unbekannt).
+func docTypeByURL(u string) string {
+	p := strings.ToLower(u)
+	if i := strings.IndexAny(p, "?#"); i >= 0 {
+		p = p[:i]
+	}
+	ext := path.Ext(p)
+	for _, e := range docExtractors {
+		for _, x := range e.Exts {
+			if ext == x {
+				return e.Type
+			}
+		}
+	}
+	return ""
+}
+
+// docTypeByContentType: Typ anhand des Content-Types ("" = unbekannt/generisch).
+func docTypeByContentType(ct string) string {
+	ct = strings.ToLower(strings.TrimSpace(ct))
+	if i := strings.IndexByte(ct, ';'); i >= 0 {
+		ct = strings.TrimSpace(ct[:i])
+	}
+	for _, e := range docExtractors {
+		for _, m := range e.Mimes {
+			if ct == m {
+				return e.Type
+			}
+		}
+	}
+	return ""
+}
+
+// documentContentTypeOK: Download-Antwort kann ein Dokument sein (generische Typen inklusive).
+func documentContentTypeOK(ct string) bool {
+	if ct == "" || docTypeByContentType(ct) != "" {
+		return true
+	}
+	ct = strings.ToLower(ct)
+	for _, generic := range []string{"application/octet-stream", "application/zip", "application/x-zip-compressed", "binary/octet-stream"} {
+		if strings.HasPrefix(ct, generic) {
+			return true
+		}
+	}
+	return false
+}
+
+// detectDocType: Magic Bytes → ZIP-Inhalt → Content-Type ("" = unbekannt).
+func detectDocType(filePath, contentType string) string {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return ""
+	}
+	head := make([]byte, docSniffBytes)
+	n, _ := io.ReadFull(f, head)
+	f.Close()
+	head = head[:n]
+
+	for _, e := range docExtractors {
+		if e.Magic != nil && e.Magic(head) {
+			return e.Type
+		}
+	}
+	if bytes.HasPrefix(head, []byte("PK\x03\x04")) {
+		if typ := zipDocType(filePath); typ != "" {
+			return typ
+		}
+	}
+	return docTypeByContentType(contentType)
+}
+
+// odfMagic: ODF legt „mimetype“ unkomprimiert als ersten Eintrag ab.
+func odfMagic(h []byte, mime string) bool {
+	return bytes.HasPrefix(h, []byte("PK\x03\x04")) && bytes.Contains(h[:minInt(len(h), 120)], []byte("mimetype"+mime))
+}
+
+// zipDocType erkennt OOXML/ODF am Inhaltsverzeichnis des Archivs.
+func zipDocType(filePath string) string {
+	zr, err := zip.OpenReader(filePath)
+	if err != nil {
+		return ""
+	}
+	defer zr.Close()
+	for _, f := range zr.File {
+		switch f.Name {
+		case "word/document.xml":
+			return docTypeDOCX
+		case "ppt/presentation.xml":
+			return docTypePPTX
+		case "mimetype":
+			b, _ := readZipEntry(f, 128)
+			switch strings.TrimSpace(string(b)) {
+			case "application/vnd.oasis.opendocument.text":
+				return docTypeODT
+			case "application/vnd.oasis.opendocument.presentation":
+				return docTypeODP
+			}
+		}
+	}
+	return ""
+}
+
+// scanDocumentCandidates erkennt den Typ und bewertet die Kandidaten (im PDF-Worker aufgerufen).
+func scanDocumentCandidates(ctx context.Context, filePath, contentType, person string) (string, pdfScanResult, error) {
+	typ := detectDocType(filePath, contentType)
+	ext := docExtractorFor(typ)
+	if ext == nil || ext.Extract == nil {
+		// PDF oder unbekannt: wie bisher der PDF-Scanner
+		res, err := scanPDFCandidates(ctx, filePath, person)
+		return docTypePDF, res, err
+	}
+
+	deadline := time.Now().Add(pdfTimeBudget)
+	if dl, ok := ctx.Deadline(); ok && dl.Before(deadline) {
+		deadline = dl
+	}
+	doc, err := ext.Extract(filePath)
+	if err != nil {
+		return typ, pdfScanResult{}, err
+	}
+	sc := newDocScorer(person, doc.Meta, deadline)
+	if sc.scanMeta(doc.Meta) {
+		return typ, sc.result(), nil
+	}
+
+	text := doc.Text
+	if len(text) > docTextBudgetBytes {
+		text = text[:docTextBudgetBytes]
+		sc.truncated = true
+	}
+	// in seitengroßen Stücken (Autorenblock nur im ersten), Schnitt an Zeilenenden
+	for first := true; text != "" && !sc.exhausted() && ctx.Err() == nil; first = false {
+		chunk := text
+		if len(chunk) > perPageTextLimitBytes {
+			chunk = chunk[:perPageTextLimitBytes]
+			if i := strings.LastIndexByte(chunk, '\n'); i > 0 {
+				chunk = chunk[:i+1]
+			}
+		}
+		text = text[len(chunk):]
+		if sc.scanText(chunk, first) {
+			break
+		}
+	}
+	if text != "" {
+		sc.truncated = true
+	}
+	return typ, sc.result(), nil
+}
+
+// -------------------- ZIP-Container --------------------
+
+// zipBudget begrenzt die insgesamt entpackten Bytes eines Dokuments.
+type zipBudget struct{ left int64 }
+
+func (b *zipBudget) read(f *zip.File) ([]byte, bool) {
+	if b.left <= 0 {
+		return nil, false
+	}
+	data, err := readZipEntry(f, minInt64(maxDocEntryBytes, b.left))
+	b.left -= int64(len(data))
+	return data, err == nil
+}
+
+func readZipEntry(f *zip.File, limit int64) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(io.LimitReader(rc, limit))
+}
+
+var (
+	reOOXMLPart = regexp.MustCompile(`^(word/(document|header\d*|footer\d*|footnotes|endnotes)|ppt/(slides/slide\d+|notesSlides/notesSlide\d+))\.xml$`)
+	reOOXMLRels = regexp.MustCompile(`^(word|ppt/slides|ppt/notesSlides)/_rels/[^/]+\.rels$`)
+	reXMLDigits = regexp.MustCompile(`\d+`)
+)
+
+// extractOOXML: Text aus DOCX/PPTX (Dokument, Kopf-/Fußzeilen, Fußnoten bzw. Folien und
+// Notizen), mailto:-Ziele aus den Relationships, Autor aus docProps/core.xml.
+func extractOOXML(filePath string) (docText, error) {
+	zr, err := zip.OpenReader(filePath)
+	if err != nil {
+		return docText{}, err
+	}
+	defer zr.Close()
+
+	var parts []*zip.File
+	var out docText
+	budget := &zipBudget{left: maxDocZipBytes}
+	for _, f := range zr.File {
+		switch {
+		case reOOXMLPart.MatchString(f.Name):
+			parts = append(parts, f)
+		case reOOXMLRels.MatchString(f.Name):
+			if data, ok := budget.read(f); ok {
+				out.Meta.Mailtos = append(out.Meta.Mailtos, xmlMailtos(data, "Target")...)
+			}
+		case f.Name == "docProps/core.xml":
+			if data, ok := budget.read(f); ok {
+				out.Meta.Authors = append(out.Meta.Authors, xmlFieldAuthors(data, "creator")...)
+				out.Meta.Emails = append(out.Meta.Emails, reEmailNormal.FindAllString(string(data), -1)...)
+			}
+		}
+	}
+	sortDocParts(parts)
+
+	var sb strings.Builder
+	for i, f := range parts {
+		if i >= maxDocEntries {
+			break
+		}
+		data, ok := budget.read(f)
+		if !ok && len(data) == 0 {
+			continue
+		}
+		txt, mailtos := xmlText(data)
+		sb.WriteString(txt)
+		sb.WriteString("\n")
+		out.Meta.Mailtos = append(out.Meta.Mailtos, mailtos...)
+	}
+	out.Text = sb.String()
+	return out, nil
+}
+
+// extractODF: Text aus ODT/ODP (content.xml und styles.xml mit Kopf-/Fußzeilen), Autor aus meta.xml.
+func extractODF(filePath string) (docText, error) {
+	zr, err := zip.OpenReader(filePath)
+	if err != nil {
+		return docText{}, err
+	}
+	defer zr.Close()
+
+	var out docText
+	var sb strings.Builder
+	budget := &zipBudget{left: maxDocZipBytes}
+	for _, name := range []string{"content.xml", "styles.xml", "meta.xml"} {
+		for _, f := range zr.File {
+			if f.Name != name {
+				continue
+			}
+			data, ok := budget.read(f)
+			if !ok && len(data) == 0 {
+				continue
+			}
+			if name == "meta.xml" {
+				out.Meta.Authors = append(out.Meta.Authors, xmlFieldAuthors(data, "creator", "initial-creator")...)
+				out.Meta.Emails = append(out.Meta.Emails, reEmailNormal.FindAllString(string(data), -1)...)
+				continue
+			}
+			txt, mailtos := xmlText(data)
+			sb.WriteString(txt)
+			sb.WriteString("\n")
+			out.Meta.Mailtos = append(out.Meta.Mailtos, mailtos...)
+		}
+	}
+	out.Text = sb.String()
+	return out, nil
+}
+
+// sortDocParts: Hauptdokument zuerst, Folien/Kopfzeilen numerisch (slide2 vor slide10).
+func sortDocParts(parts []*zip.File) {
+	key := func(name string) (int, string, int) {
+		rank := 1
+		if name == "word/document.xml" || strings.HasPrefix(name, "ppt/slides/") {
+			rank = 0
+		}
+		n, _ := strconv.Atoi(reXMLDigits.FindString(path.Base(name)))
+		return rank, reXMLDigits.ReplaceAllString(name, ""), n
+	}
+	sort.SliceStable(parts, func(i, j int) bool {
+		ri, si, ni := key(parts[i].Name)
+		rj, sj, nj := key(parts[j].Name)
+		if ri != rj {
+			return ri < rj
+		}
+		if si != sj {
+			return si < sj
+		}
+		return ni < nj
+	})
+}
+
+// xmlText sammelt die Textknoten eines OOXML-/ODF-Teils. Absätze, Tabellenzellen und Umbrüche
+// werden zu Zeilen, Tabs und ODF-Leerzeichen (<text:s/>) zu Leerzeichen; mailto:-Ziele aus href.
+func xmlText(data []byte) (string, []string) {
+	var sb strings.Builder
+	var mailtos []string
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.Strict = false
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			break
+		}
+		switch t := tok.(type) {
+		case xml.CharData:
+			sb.Write(t)
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "tab", "s":
+				sb.WriteByte(' ')
+			case "br", "cr", "line-break":
+				sb.WriteByte('\n')
+			}
+			for _, a := range t.Attr {
+				if a.Name.Local == "href" {
+					if addr := extractAddressFromMailto(strings.TrimSpace(a.Value)); addr != "" {
+						mailtos = append(mailtos, addr)
+					}
+				}
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "p", "h", "tc", "table-cell", "list-item":
+				sb.WriteByte('\n')
+			}
+		}
+	}
+	return sb.String(), mailtos
+}
+
+// xmlMailtos: mailto:-Adressen aus einem Attribut (z. B. Relationship-Target).
+func xmlMailtos(data []byte, attr string) []string {
+	var out []string
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.Strict = false
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return out
+		}
+		if se, ok := tok.(xml.StartElement); ok {
+			for _, a := range se.Attr {
+				if a.Name.Local == attr {
+					if addr := extractAddressFromMailto(strings.TrimSpace(a.Value)); addr != "" {
+						out = append(out, addr)
+					}
+				}
+			}
+		}
+	}
+}
+
+// xmlFieldAuthors: Inhalt der genannten Elemente (dc:creator …) als Autorenliste.
+func xmlFieldAuthors(data []byte, fields ...string) []string {
+	var out []string
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.Strict = false
+	in := false
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return out
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			in = false
+			for _, f := range fields {
+				in = in || t.Name.Local == f
+			}
+		case xml.CharData:
+			if in {
+				out = append(out, splitPDFAuthors(string(t))...)
+			}
+		case xml.EndElement:
+			in = false
+		}
+	}
+}
+
+// -------------------- RTF --------------------
+
+// rtfSkipDest: Destinations ohne Fließtext (Tabellen, Bilder, Binärdaten).
+var rtfSkipDest = map[string]bool{
+	"fonttbl": true, "colortbl": true, "stylesheet": true, "info": true, "pict": true,
+	"object": true, "objdata": true, "themedata": true, "colorschememapping": true,
+	"datastore": true, "latentstyles": true, "listtable": true, "listoverridetable": true,
+	"rsidtbl": true, "generator": true, "xmlnstbl": true, "filetbl": true, "revtbl": true,
+	"fldrslt": false, "fldinst": false, "author": false,
+}
+
+// cp1252High: Windows-1252 0x80–0x9F (Rest von 0xA0 an = Latin-1).
+var cp1252High = []rune("€\u0081‚ƒ„…†‡ˆ‰Š‹Œ\u008dŽ\u008f\u0090‘’“”•–—˜™š›œ\u009džŸ")
+
+func cp1252Rune(b byte) rune {
+	if b >= 0x80 && b <= 0x9f {
+		return cp1252High[b-0x80]
+	}
+	return rune(b)
+}
+
+// extractRTF: Fließtext, Feldbefehle (HYPERLINK "mailto:…") und \author aus dem Info-Block.
+func extractRTF(filePath string) (docText, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return docText{}, err
+	}
+	text, authors := rtfText(data)
+	out := docText{Text: text}
+	for _, a := range authors {
+		out.Meta.Authors = append(out.Meta.Authors, splitPDFAuthors(a)...)
+	}
+	for _, m := range reRTFMailto.FindAllStringSubmatch(text, -1) {
+		if addr := extractAddressFromMailto(m[1]); addr != "" {
+			out.Meta.Mailtos = append(out.Meta.Mailtos, addr)
+		}
+	}
+	return out, nil
+}
+
+var reRTFMailto = regexp.MustCompile(`(?i)HYPERLINK\s+"(mailto:[^"]+)"`)
+
+// rtfText ist ein kleiner RTF-Leser: Gruppen, Steuerwörter, \'hh, \uN (mit \ucN-Ersatzzeichen).
+func rtfText(data []byte) (string, []string) {
+	type state struct {
+		skip   bool
+		author bool
+		uc     int
+	}
+	var (
+		sb      strings.Builder
+		author  strings.Builder
+		authors []string
+		stack   []state
+		cur     = state{uc: 1}
+		pending = 0 // noch zu überspringende Ersatzzeichen nach \uN
+		star    = false
+	)
+	emit := func(r rune) {
+		if pending > 0 {
+			pending--
+			return
+		}
+		switch {
+		case cur.author:
+			author.WriteRune(r)
+		case !cur.skip:
+			sb.WriteRune(r)
+		}
+	}
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch c {
+		case '{':
+			stack = append(stack, cur)
+			star = false
+		case '}':
+			if cur.author {
+				authors = append(authors, strings.TrimSpace(author.String()))
+				author.Reset()
+			}
+			if n := len(stack); n > 0 {
+				cur = stack[n-1]
+				stack = stack[:n-1]
+			}
+		case '\r', '\n':
+		case '\\':
+			if i+1 >= len(data) {
+				break
+			}
+			n := data[i+1]
+			switch {
+			case n == '\'' && i+3 < len(data):
+				if v, err := strconv.ParseUint(string(data[i+2:i+4]), 16, 8); err == nil {
+					emit(cp1252Rune(byte(v)))
+				}
+				i += 3
+			case n == '*':
+				star = true
+				i++
+			case n == '\\' || n == '{' || n == '}':
+				emit(rune(n))
+				i++
+			case n == '~':
+				emit(' ')
+				i++
+			case n == '_':
+				emit('-')
+				i++
+			case n == '\r' || n == '\n':
+				emit('\n')
+				i++
+			case n >= 'a' && n <= 'z' || n >= 'A' && n <= 'Z':
+				j := i + 1
+				for j < len(data) && (data[j] >= 'a' && data[j] <= 'z' || data[j] >= 'A' && data[j] <= 'Z') {
+					j++
+				}
+				word := string(data[i+1 : j])
+				k := j
+				if k < len(data) && data[k] == '-' {
+					k++
+				}
+				for k < len(data) && data[k] >= '0' && data[k] <= '9' {
+					k++
+				}
+				param, hasParam := 0, k > j
+				if hasParam {
+					param, _ = strconv.Atoi(string(data[j:k]))
+				}
+				if k < len(data) && data[k] == ' ' {
+					k++ // Trenner gehört zum Steuerwort
+				}
+				i = k - 1
+
+				if skip, known := rtfSkipDest[word]; known || star {
+					switch {
+					case word == "author":
+						cur.author = true
+					case skip || (star && !known):
+						cur.skip = true
+					}
+					star = false
+					continue
+				}
+				switch word {
+				case "par", "line", "row", "sect", "page":
+					emit('\n')
+				case "tab", "cell":
+					emit(' ')
+				case "uc":
+					cur.uc = param
+				case "u":
+					if param < 0 {
+						param += 65536
+					}
+					emit(rune(param))
+					pending = cur.uc
+				case "bin":
+					i += maxInt(0, param) // Binärdaten überspringen
+				}
+			default:
+				i++ // Steuersymbol ohne Text (\-, \| …)
+			}
+		default:
+			emit(cp1252Rune(c))
+		}
+	}
+	return sb.String(), authors
+}
+
+// -------------------- PostScript --------------------
+
+// extractPostScript: String-Literale „(…) show“ in Quelltext-Reihenfolge; showpage trennt Seiten.
+func extractPostScript(filePath string) (docText, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return docText{}, err
+	}
+	var sb strings.Builder
+	for i := 0; i < len(data); i++ {
+		switch data[i] {
+		case '%': // Kommentar bis Zeilenende (DSC-Kommentare wie %%Title inklusive)
+			j := bytes.IndexByte(data[i:], '\n')
+			if j < 0 {
+				i = len(data)
+				continue
+			}
+			if bytes.HasPrefix(data[i:], []byte("%%Title:")) || bytes.HasPrefix(data[i:], []byte("%%For:")) {
+				sb.Write(data[i : i+j])
+				sb.WriteByte('\n')
+			}
+			i += j
+		case '(':
+			lit, end := psLiteral(data, i)
+			sb.WriteString(lit)
+			sb.WriteByte(' ')
+			i = end
+		case 's':
+			if bytes.HasPrefix(data[i:], []byte("showpage")) {
+				sb.WriteByte('\n')
+				i += len("showpage") - 1
+			}
+		}
+	}
+	return docText{Text: sb.String()}, nil
+}
+
+// psLiteral liest ein PostScript-String-Literal ab data[start] == '(' (verschachtelte Klammern,
+// Escapes, Oktal) und liefert Text und Index der schließenden Klammer.
+func psLiteral(data []byte, start int) (string, int) {
+	var sb strings.Builder
+	depth := 0
+	for i := start; i < len(data); i++ {
+		c := data[i]
+		switch c {
+		case '(':
+			if depth > 0 {
+				sb.WriteByte(c)
+			}
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return sb.String(), i
+			}
+			sb.WriteByte(c)
+		case '\\':
+			if i+1 >= len(data) {
+				return sb.String(), i
+			}
+			i++
+			switch e := data[i]; {
+			case e >= '0' && e <= '7':
+				j := i
+				for j < len(data) && j < i+3 && data[j] >= '0' && data[j] <= '7' {
+					j++
+				}
+				v, _ := strconv.ParseUint(string(data[i:j]), 8, 8)
+				sb.WriteRune(cp1252Rune(byte(v)))
+				i = j - 1
+			case e == 'n':
+				sb.WriteByte('\n')
+			case e == '\r' || e == '\n':
+			default:
+				sb.WriteByte(e)
+			}
+		default:
+			sb.WriteRune(cp1252Rune(c))
+		}
+	}
+	return sb.String(), len(data) - 1
+}
+
+func minInt64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
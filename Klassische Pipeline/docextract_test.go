@@ -0,0 +1,247 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Kleine Dokumente werden im Speicher gebaut und nur für die Extraktoren
+// (die Pfade erwarten) in ein Temp-Verzeichnis geschrieben.
+
+type zipEntry struct {
+	name, body string
+	store      bool // unkomprimiert (ODF: „mimetype“)
+}
+
+func buildZip(t *testing.T, entries ...zipEntry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, e := range entries {
+		method := zip.Deflate
+		if e.store {
+			method = zip.Store
+		}
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: e.name, Method: method})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(e.body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func writeTemp(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(p, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+const (
+	testDocxDocument = `<?xml version="1.0"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>
+<w:p><w:r><w:t>Jane Doe</w:t></w:r><w:r><w:tab/><w:t>Curriculum Vitae</w:t></w:r></w:p>
+<w:p><w:r><w:t>jane.doe@uni-x.de</w:t></w:r></w:p>
+</w:body></w:document>`
+	testDocxRels = `<?xml version="1.0"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="hyperlink" Target="mailto:jdoe@uni-x.de" TargetMode="External"/>
+</Relationships>`
+	testDocxCore = `<?xml version="1.0"?>
+<cp:coreProperties xmlns:cp="x" xmlns:dc="http://purl.org/dc/elements/1.1/"><dc:creator>Jane Doe</dc:creator></cp:coreProperties>`
+	testODTContent = `<?xml version="1.0"?>
+<office:document-content xmlns:office="o" xmlns:text="t" xmlns:xlink="x"><office:body><office:text>
+<text:h>John<text:s/>Smith</text:h>
+<text:p>Mail: <text:a xlink:href="mailto:john.smith@uni-y.de">john.smith@uni-y.de</text:a></text:p>
+</office:text></office:body></office:document-content>`
+	testODTMeta = `<?xml version="1.0"?>
+<office:document-meta xmlns:office="o" xmlns:meta="m"><office:meta><meta:initial-creator>John Smith</meta:initial-creator></office:meta></office:document-meta>`
+	testODTMime = "application/vnd.oasis.opendocument.text"
+)
+
+func testDOCX(t *testing.T) []byte {
+	return buildZip(t,
+		zipEntry{name: "[Content_Types].xml", body: `<Types/>`},
+		zipEntry{name: "word/footer1.xml", body: `<w:ftr xmlns:w="w"><w:p><w:r><w:t>Fußzeile</w:t></w:r></w:p></w:ftr>`},
+		zipEntry{name: "word/document.xml", body: testDocxDocument},
+		zipEntry{name: "word/_rels/document.xml.rels", body: testDocxRels},
+		zipEntry{name: "docProps/core.xml", body: testDocxCore},
+	)
+}
+
+func testODT(t *testing.T) []byte {
+	return buildZip(t,
+		zipEntry{name: "mimetype", body: testODTMime, store: true},
+		zipEntry{name: "content.xml", body: testODTContent},
+		zipEntry{name: "meta.xml", body: testODTMeta},
+	)
+}
+
+func TestDetectDocType(t *testing.T) {
+	tests := []struct {
+		name, file  string
+		data        []byte
+		contentType string
+		want        string
+	}{
+		{"PDF", "a.bin", []byte("%PDF-1.7\n%âãÏÓ\n"), "", docTypePDF},
+		{"RTF mit Leerraum", "a.bin", []byte("\r\n{\\rtf1\\ansi Hallo}"), "", docTypeRTF},
+		{"PostScript", "a.bin", []byte("%!PS-Adobe-3.0\n(x) show\n"), "", docTypePS},
+		{"DOCX am Inhalt", "a.zip", testDOCX(t), "application/octet-stream", docTypeDOCX},
+		{"ODT am mimetype", "a.zip", testODT(t), "", docTypeODT},
+		{"unbekannt → Content-Type", "a.bin", []byte("hallo"), "application/rtf", docTypeRTF},
+		{"unbekannt", "a.bin", []byte("<html></html>"), "text/html", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := writeTemp(t, tt.file, tt.data)
+			if got := detectDocType(p, tt.contentType); got != tt.want {
+				t.Errorf("detectDocType = %q, erwartet %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractOOXML(t *testing.T) {
+	out, err := extractOOXML(writeTemp(t, "cv.docx", testDOCX(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.Text, "Jane Doe Curriculum Vitae\n") || !strings.Contains(out.Text, "jane.doe@uni-x.de") {
+		t.Errorf("Text = %q", out.Text)
+	}
+	if i, j := strings.Index(out.Text, "Jane Doe"), strings.Index(out.Text, "Fußzeile"); i < 0 || j < i {
+		t.Errorf("Hauptdokument nicht vor der Fußzeile: %q", out.Text)
+	}
+	if !reflect.DeepEqual(out.Meta.Mailtos, []string{"jdoe@uni-x.de"}) {
+		t.Errorf("Mailtos = %v", out.Meta.Mailtos)
+	}
+	if !reflect.DeepEqual(out.Meta.Authors, []string{"Jane Doe"}) {
+		t.Errorf("Authors = %v", out.Meta.Authors)
+	}
+}
+
+func TestExtractODF(t *testing.T) {
+	out, err := extractODF(writeTemp(t, "cv.odt", testODT(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.Text, "John Smith\n") || !strings.Contains(out.Text, "Mail: john.smith@uni-y.de") {
+		t.Errorf("Text = %q", out.Text)
+	}
+	if !reflect.DeepEqual(out.Meta.Mailtos, []string{"john.smith@uni-y.de"}) {
+		t.Errorf("Mailtos = %v", out.Meta.Mailtos)
+	}
+	if !reflect.DeepEqual(out.Meta.Authors, []string{"John Smith"}) {
+		t.Errorf("Authors = %v", out.Meta.Authors)
+	}
+}
+
+func TestSortDocParts(t *testing.T) {
+	names := []string{
+		"ppt/notesSlides/notesSlide1.xml", "ppt/slides/slide10.xml", "word/header2.xml",
+		"ppt/slides/slide2.xml", "word/document.xml", "word/footer1.xml", "ppt/slides/slide1.xml",
+	}
+	parts := make([]*zip.File, len(names))
+	for i, n := range names {
+		parts[i] = &zip.File{FileHeader: zip.FileHeader{Name: n}}
+	}
+	sortDocParts(parts)
+	var got []string
+	for _, f := range parts {
+		got = append(got, f.Name)
+	}
+	want := []string{
+		"ppt/slides/slide1.xml", "ppt/slides/slide2.xml", "ppt/slides/slide10.xml", "word/document.xml",
+		"ppt/notesSlides/notesSlide1.xml", "word/footer1.xml", "word/header2.xml",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sortDocParts = %v, erwartet %v", got, want)
+	}
+}
+
+func TestXMLText(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		mailtos []string
+	}{
+		{`<p>a<tab/>b</p><p>c</p>`, "a b\nc\n", nil},
+		{`<text:p xmlns:text="t">x<text:s/>y<text:line-break/>z</text:p>`, "x y\nz\n", nil},
+		{`<tr><tc>A</tc><tc>B</tc></tr>`, "A\nB\n", nil},
+		{`<p><a href=" mailto:jane.doe@uni-x.de?subject=Hi ">Mail</a></p>`, "Mail\n", []string{"jane.doe@uni-x.de"}},
+		{`<p>kaputt<b>`, "kaputt", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, mailtos := xmlText([]byte(tt.input))
+			if got != tt.want || !reflect.DeepEqual(mailtos, tt.mailtos) {
+				t.Errorf("xmlText = %q, %v; erwartet %q, %v", got, mailtos, tt.want, tt.mailtos)
+			}
+		})
+	}
+}
+
+func TestRTFText(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		authors []string
+	}{
+		{"Absatz und Tab", `{\rtf1\ansi Jane Doe\par Mail:\tab jane\'40uni-x.de}`, "Jane Doe\nMail: jane@uni-x.de", nil},
+		{"Schriftentabelle übersprungen", `{\rtf1{\fonttbl{\f0 Arial;}}{\*\generator Word;}Text}`, "Text", nil},
+		{"unbekannte Sternchen-Destination", `{\rtf1{\*\foo geheim}sichtbar}`, "sichtbar", nil},
+		{"Unicode mit Ersatzzeichen", `{\rtf1 M\u252?ller \uc0\u8364 X}`, "Müller €X", nil},
+		{"cp1252", `{\rtf1 M\'fcller \'80}`, "Müller €", nil},
+		{"Escapes", `{\rtf1 a\{b\}c\\d\~e\_f}`, "a{b}c\\d e-f", nil},
+		{"Autor aus Info", `{\rtf1{\info{\author Jane Doe}{\title CV}}Hallo}`, "Hallo", []string{"Jane Doe"}},
+		{"Hyperlink-Feld", `{\rtf1{\field{\*\fldinst HYPERLINK "mailto:jd@uni-x.de"}{\fldrslt jd}}}`, `HYPERLINK "mailto:jd@uni-x.de"jd`, nil},
+		{"abgeschnitten", `{\rtf1 Hallo\`, "Hallo", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, authors := rtfText([]byte(tt.input))
+			if got != tt.want || !reflect.DeepEqual(authors, tt.authors) {
+				t.Errorf("rtfText = %q, %v; erwartet %q, %v", got, authors, tt.want, tt.authors)
+			}
+		})
+	}
+}
+
+func TestPSLiteral(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		end   int
+	}{
+		{`(Jane Doe) show`, "Jane Doe", 9},
+		{`(a (b) c) show`, "a (b) c", 8},
+		{`(jane\100uni-x.de)`, "jane@uni-x.de", 17},
+		{`(a\)b\\c\nd)`, "a)b\\c\nd", 11},
+		{"(zeilen\\\numbruch)", "zeilenumbruch", 16},
+		{`(M\374ller)`, "Müller", 10},
+		{`(offen`, "offen", 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, end := psLiteral([]byte(tt.input), 0)
+			if got != tt.want || end != tt.end {
+				t.Errorf("psLiteral = %q, %d; erwartet %q, %d", got, end, tt.want, tt.end)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// =================== Dokument-Links ohne Endung ===================
+//
+// arXiv (/pdf/2301.01234), Repositorien (bitstream/handle/…?sequence=1) und
+// Download-Skripte (download.php?id=…) liefern PDFs ohne „.pdf“ im Pfad.
+// Solche URLs werden per HEAD (Content-Type, Content-Disposition) und
+// notfalls per Range-GET der ersten Bytes (Magic Bytes) geprüft. Das
+// Ergebnis wird je URL im Speicher und im Platten-Cache gehalten.
+
+const (
+	sniffTimeout      = 8 * time.Second
+	sniffBudget       = 20 * time.Second // alle Prüfungen einer Suche zusammen
+	sniffMaxPerSearch = 6                // höchstens so viele Prüfungen je Suche
+	sniffNone         = "none"
+	sniffViaError     = "error" // keine verwertbare Antwort: Netz- oder HTTP-Fehler (nicht auf Platte cachen)
+)
+
+var (
+	reDocLikeURL = regexp.MustCompile(`(?i)(/pdfs?/|/download|/bitstream/|/viewcontent\.cgi|/get_?file|/fulltext|/attachments?/|/files?/|/documents?/|/servlets?/|/record/\d+/files/|[?&](sequence|format|type|download|file|attachment|dl)=|\.(php|aspx?|ashx|jsp|cgi)(\?|$))`)
+	reNonDocExt  = regexp.MustCompile(`(?i)\.(html?|jpe?g|png|gif|svg|css|js|zip|gz|mp4|mp3)$`)
+)
+
+var sniffMemo sync.Map // URL → Dokumenttyp bzw. sniffNone
+
+// looksLikeDocumentURL: Pfad/Query deuten auf einen Download ohne Dokument-Endung.
+func looksLikeDocumentURL(u string) bool {
+	p := u
+	if i := strings.IndexByte(p, '#'); i >= 0 {
+		p = p[:i]
+	}
+	path := p
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	return !reNonDocExt.MatchString(path) && reDocLikeURL.MatchString(p)
+}
+
+// sniffDocumentLinks prüft die gesammelten Kandidaten einer Suche nacheinander
+// mit eigenem Gesamtbudget und liefert höchstens max Dokument-Links.
+func sniffDocumentLinks(urls []string, max int) []string {
+	ctx, cancel := context.WithTimeout(context.Background(), sniffBudget)
+	defer cancel()
+	var out []string
+	for _, u := range urls {
+		if len(out) >= max || ctx.Err() != nil {
+			break
+		}
+		if docTypeEnabled(sniffDocumentType(ctx, u)) {
+			out = append(out, u)
+		}
+	}
+	return out
+}
+
+// sniffDocumentType liefert den Dokumenttyp hinter u ("" = kein Dokument/unbekannt).
+func sniffDocumentType(ctx context.Context, u string) string {
+	if v, ok := sniffMemo.Load(u); ok {
+		return sniffResult(v.(string))
+	}
+	if e, ok := cacheGet(cacheKindSniff, u); ok {
+		sniffMemo.Store(u, string(e.Body))
+		return sniffResult(string(e.Body))
+	}
+	if runCfg.Offline || robotsCheck(u, "Sniff") != nil {
+		return ""
+	}
+
+	typ, via := sniffHead(ctx, u)
+	if typ == "" {
+		typ, via = sniffRange(ctx, u)
+	}
+	if typ == "" {
+		typ = sniffNone
+	}
+	if via == sniffViaError && ctx.Err() != nil {
+		return "" // Budget der Suche erschöpft, nicht der Host
+	}
+	sniffMemo.Store(u, typ)
+	if via != sniffViaError { // Netzfehler nicht dauerhaft merken
+		cachePut(cacheKindSniff, u, http.StatusOK, "text/plain", "", []byte(typ))
+	}
+	if typ != sniffNone {
+		fmt.Printf("🔬 [Sniff] %s → %s (%s)\n", u, typ, via)
+	}
+	return sniffResult(typ)
+}
+
+func sniffResult(typ string) string {
+	if typ == sniffNone {
+		return ""
+	}
+	return typ
+}
+
+// sniffHead: Content-Type bzw. Dateiname aus Content-Disposition. HTML gilt als endgültig.
+// Fehlerstatus (viele Server verweigern HEAD mit 403/405) überlässt die Entscheidung dem Range-GET.
+func sniffHead(ctx context.Context, u string) (string, string) {
+	resp, err := sniffRequest(ctx, http.MethodHead, u)
+	if err != nil {
+		return "", sniffViaError
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return "", sniffViaError
+	}
+	if typ := sniffHeaders(resp.Header); typ != "" {
+		return typ, "HEAD"
+	}
+	if ct := strings.ToLower(resp.Header.Get("Content-Type")); strings.HasPrefix(ct, "text/html") {
+		return sniffNone, "HEAD" // Landingpage, kein Range-GET nötig
+	}
+	return "", ""
+}
+
+// sniffRange: erste Bytes per Range-GET holen und die Magic Bytes prüfen. Nur eine
+// erfolgreiche Antwort ohne Dokument-Signatur gilt als endgültig „kein Dokument“;
+// 403/404, 429 und 5xx können sich ändern (Sperre, Drosselung, Ausfall).
+func sniffRange(ctx context.Context, u string) (string, string) {
+	resp, err := sniffRequest(ctx, http.MethodGet, u)
+	if err != nil {
+		return "", sniffViaError
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return "", sniffViaError
+	}
+	if typ := sniffHeaders(resp.Header); typ != "" {
+		return typ, "GET"
+	}
+	head, _ := io.ReadAll(io.LimitReader(resp.Body, docSniffBytes))
+	for _, e := range docExtractors {
+		if e.Magic != nil && e.Magic(head) {
+			return e.Type, "magic"
+		}
+	}
+	return sniffNone, "magic"
+}
+
+func sniffRequest(ctx context.Context, method, u string) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, sniffTimeout)
+	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64)")
+	if method == http.MethodGet {
+		req.Header.Set("Range", "bytes=0-1023")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = cancelOnClose{resp.Body, cancel}
+	return resp, nil
+}
+
+// sniffHeaders: Typ aus Content-Type oder Content-Disposition ("" = nicht eindeutig).
+func sniffHeaders(h http.Header) string {
+	if typ := docTypeByContentType(h.Get("Content-Type")); typ != "" {
+		return typ
+	}
+	if _, params, err := mime.ParseMediaType(h.Get("Content-Disposition")); err == nil {
+		if typ := docTypeByURL(params["filename"]); typ != "" {
+			return typ
+		}
+	}
+	return ""
+}
+
+// cancelOnClose gibt den Request-Kontext frei, sobald der Body geschlossen ist.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLooksLikeDocumentURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://arxiv.org/pdf/2301.01234", true},
+		{"https://arxiv.org/pdf/2301.01234v2#page=3", true},
+		{"https://repo.uni-x.de/bitstream/handle/123/456/thesis?sequence=1", true},
+		{"https://www.uni-x.de/download.php?id=42", true},
+		{"https://scholarworks.example.edu/cgi/viewcontent.cgi?article=1001&context=etd", true},
+		{"https://www.uni-x.de/files/cv", true},
+		{"https://www.uni-x.de/team/jane-doe.html", false},
+		{"https://www.uni-x.de/files/portrait.jpg", false},
+		{"https://www.uni-x.de/pdfs/overview.png?download=1", false},
+		{"https://www.uni-x.de/team/jane-doe", false},
+		{"https://www.uni-x.de/", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if got := looksLikeDocumentURL(tt.url); got != tt.want {
+				t.Errorf("looksLikeDocumentURL(%q) = %v, erwartet %v", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+// Nur endgültige Antworten landen im Platten-Cache; HTTP-Fehler bleiben im Speicher.
+func TestSniffDocumentTypeStatus(t *testing.T) {
+	defer func(c runConfig) { runCfg = c }(runCfg)
+	runCfg.Offline, runCfg.RespectRobots = false, false
+	runCfg.CacheEnabled, runCfg.CacheDir = true, t.TempDir()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pdf":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Write([]byte("%PDF-1.7\n"))
+		case "/head403":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.Write([]byte("%PDF-1.4\n"))
+		case "/landing":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html></html>"))
+		case "/plain":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Write([]byte("kein Dokument"))
+		case "/busy":
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/down":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case "/gone":
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		path   string
+		want   string
+		cached bool
+	}{
+		{"/pdf", docTypePDF, true},
+		{"/head403", docTypePDF, true},
+		{"/landing", "", true},
+		{"/plain", "", true},
+		{"/busy", "", false},
+		{"/down", "", false},
+		{"/gone", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			u := srv.URL + tt.path
+			if got := sniffDocumentType(context.Background(), u); got != tt.want {
+				t.Errorf("sniffDocumentType = %q, erwartet %q", got, tt.want)
+			}
+			if _, ok := cacheGet(cacheKindSniff, u); ok != tt.cached {
+				t.Errorf("im Platten-Cache = %v, erwartet %v", ok, tt.cached)
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// =================== Dokument-Store (SHA-256) ===================
+//
+// Co-Autoren einer Liste finden oft dieselben PDFs. Jedes gescannte Dokument
+// wird unter dem SHA-256 seines Inhalts abgelegt – mit allen Adressen samt
+// Herkunft, den Metadaten-Autoren und dem Autorenblock, nicht nur mit dem
+// besten Treffer der gerade gesuchten Person. Spätere Personen werden daraus
+// neu bewertet, ohne Download und ohne Worker:
+//   - bekannte URL oder bekannter Inhalt (gleicher Hash unter anderer URL)
+//   - Person steht in der Autorenliste eines Dokuments (noch vor der Suche)
+//
+// Records und URL-Index liegen im Platten-Cache (auch über Läufe hinweg), der
+// Autorenindex nur im Speicher des laufenden Batches. Nur vollständige Scans
+// gelten als Antwort für beliebige Personen; endete der frühere Scan per
+// Early-Exit, muss die neue Bewertung selbst sicher sein.
+
+const (
+	cacheKindDoc    = "doc"    // Dokument-Record je SHA-256
+	cacheKindDocURL = "docurl" // URL → SHA-256
+)
+
+// docRecord: alles, was aus einem Dokument für beliebige Personen gebraucht wird.
+type docRecord struct {
+	SHA256 string    `json:"sha256"`
+	Type   string    `json:"type"`
+	URLs   []string  `json:"urls"`
+	Stored time.Time `json:"stored"`
+	docHarvest
+}
+
+type docStoreIndex struct {
+	sync.Mutex
+	bySHA    map[string]*docRecord
+	byURL    map[string]string   // URL → SHA-256
+	byAuthor map[string][]string // Nachname (klein, gefaltet) → SHA-256
+}
+
+var docStore = &docStoreIndex{
+	bySHA:    map[string]*docRecord{},
+	byURL:    map[string]string{},
+	byAuthor: map[string][]string{},
+}
+
+// fileSHA256 hasht eine heruntergeladene Datei.
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// docStoreByURL: Record zu einer schon gesehenen URL (Speicher, sonst Platten-Cache).
+func docStoreByURL(u string) *docRecord {
+	docStore.Lock()
+	sha, ok := docStore.byURL[u]
+	docStore.Unlock()
+	if !ok {
+		e, hit := cacheGet(cacheKindDocURL, u)
+		if !hit {
+			return nil
+		}
+		sha = string(e.Body)
+	}
+	return docStoreBySHA(sha)
+}
+
+// docStoreBySHA: Record zu einem Inhalts-Hash (Speicher, sonst Platten-Cache) als Kopie,
+// weil die Indizierung denselben Record parallel ergänzt.
+func docStoreBySHA(sha string) *docRecord {
+	docStore.Lock()
+	rec := docStore.bySHA[sha]
+	if rec != nil {
+		rec = rec.snapshot()
+	}
+	docStore.Unlock()
+	if rec != nil {
+		return rec
+	}
+	e, ok := cacheGet(cacheKindDoc, sha)
+	if !ok {
+		return nil
+	}
+	rec = &docRecord{}
+	if json.Unmarshal(e.Body, rec) != nil || rec.SHA256 != sha {
+		return nil
+	}
+	docStore.Lock()
+	if cur := docStore.bySHA[sha]; cur != nil { // parallel geladen oder abgelegt
+		rec = cur
+	} else {
+		docStore.index(rec)
+	}
+	rec = rec.snapshot()
+	docStore.Unlock()
+	return rec
+}
+
+// docStorePut legt den Harvest eines Scans ab bzw. ergänzt einen vorhandenen Record.
+func docStorePut(sha, u, typ string, h docHarvest) *docRecord {
+	docStore.Lock()
+	rec := docStore.bySHA[sha]
+	if rec == nil {
+		rec = &docRecord{SHA256: sha, Type: typ, docHarvest: docHarvest{Complete: h.Complete}}
+	}
+	rec.Stored = time.Now()
+	rec.Complete = rec.Complete || h.Complete
+	rec.URLs = appendUnique(rec.URLs, u)
+	rec.Authors = appendUnique(rec.Authors, h.Authors...)
+	if rec.Block == nil {
+		rec.Block = h.Block
+	}
+	seen := make(map[string]struct{}, len(rec.Addresses))
+	for _, a := range rec.Addresses {
+		seen[a.Email] = struct{}{}
+	}
+	for _, a := range h.Addresses {
+		if _, dup := seen[a.Email]; !dup {
+			seen[a.Email] = struct{}{}
+			rec.Addresses = append(rec.Addresses, a)
+		}
+	}
+	docStore.index(rec)
+	body, err := json.Marshal(rec)
+	rec = rec.snapshot()
+	docStore.Unlock()
+
+	if err == nil {
+		cachePut(cacheKindDoc, sha, 200, "application/json", "", body)
+		cachePut(cacheKindDocURL, u, 200, "text/plain", "", []byte(sha))
+	}
+	return rec
+}
+
+// snapshot: Kopie für Leser außerhalb des Locks (Lock muss gehalten werden). Die Listen
+// werden nur angehängt und der Autorenblock nur einmal gesetzt; die Kopie sieht also
+// einen festen Stand, ohne dass Einträge kopiert werden müssen.
+func (rec *docRecord) snapshot() *docRecord {
+	c := *rec
+	return &c
+}
+
+// index trägt URLs und Autoren-Nachnamen ein (Lock muss gehalten werden).
+func (s *docStoreIndex) index(rec *docRecord) {
+	s.bySHA[rec.SHA256] = rec
+	for _, u := range rec.URLs {
+		s.byURL[u] = rec.SHA256
+	}
+	for _, last := range rec.authorLastNames() {
+		s.byAuthor[last] = appendUnique(s.byAuthor[last], rec.SHA256)
+	}
+}
+
+// authorLastNames: Nachnamen aus Metadaten und Autorenblock (klein, ASCII-gefaltet).
+func (rec *docRecord) authorLastNames() []string {
+	var out []string
+	for _, a := range rec.Authors {
+		if _, last := splitPersonName(a); last != "" {
+			out = appendUnique(out, last)
+		}
+	}
+	if rec.Block != nil {
+		for _, a := range rec.Block.Authors {
+			if a.Last != "" {
+				out = appendUnique(out, a.Last)
+			}
+		}
+	}
+	return out
+}
+
+// hasAuthor: die Person steht in den Metadaten oder im Autorenblock.
+func (rec *docRecord) hasAuthor(first, last string) bool {
+	return pdfMeta{Authors: rec.Authors}.authorMatches(first, last) || rec.Block.targetIndex(first, last) >= 0
+}
+
+// rescore bewertet die gespeicherten Adressen für eine andere Person – wie im Worker.
+func (rec *docRecord) rescore(person string) pdfScanResult {
+	sc := newDocScorer(person, pdfMeta{Authors: rec.Authors}, time.Now().Add(pdfTimeBudget))
+	if rec.Block != nil {
+		sc.authors = rec.Block
+		sc.target = rec.Block.targetIndex(sc.first, sc.last)
+	}
+	for _, a := range rec.Addresses {
+		sc.consider(a.Email, a.Via)
+	}
+	return sc.result()
+}
+
+// usable: Ergebnis aus dem Store reicht ohne neuen Scan (vollständiger Scan oder sicherer Treffer).
+func (rec *docRecord) usable(res pdfScanResult) bool {
+	return rec.Complete || res.Score >= highConfidenceCutoff
+}
+
+// splitPersonName: reiner Personenname („Jane Doe“, „Doe, Jane“) → Vorname, Nachname
+// (klein, ASCII-gefaltet). Für Autorenfelder und die Namensspalte der CSV.
+func splitPersonName(name string) (first, last string) {
+	name = asciiFold(strings.ToLower(strings.TrimSpace(name)))
+	if i := strings.IndexByte(name, ','); i >= 0 && strings.Count(name, ",") == 1 {
+		name = strings.TrimSpace(name[i+1:]) + " " + strings.TrimSpace(name[:i])
+	}
+	toks := strings.Fields(name)
+	if len(toks) == 0 {
+		return "", ""
+	}
+	last = strings.Trim(toks[len(toks)-1], ".")
+	if len(toks) > 1 {
+		first = strings.Trim(toks[0], ".")
+	}
+	return first, last
+}
+
+// docStoreForPerson: gespeicherte Dokumente, in denen die Person (Namensspalte) als Autor steht.
+func docStoreForPerson(name string) []*docRecord {
+	first, last := splitPersonName(name)
+	if last == "" {
+		return nil
+	}
+	docStore.Lock()
+	shas := append([]string(nil), docStore.byAuthor[last]...)
+	docStore.Unlock()
+
+	var out []*docRecord
+	for _, sha := range shas {
+		if rec := docStoreBySHA(sha); rec != nil && rec.hasAuthor(first, last) {
+			out = append(out, rec)
+		}
+	}
+	return out
+}
+
+// docRecordMode: Abrufmodus für die Ergebniszeile.
+func docRecordMode(typ string) string {
+	if typ == docTypePDF || typ == "" {
+		return fetchModePDF
+	}
+	return fetchModeDocument
+}
+
+func logDocReuse(rec *docRecord, person, why string, res pdfScanResult) {
+	fmt.Printf("♻️ [DocStore] %s: %s %.12s (%s, %d Adressen) → %s/%d\n",
+		person, why, rec.SHA256, rec.Type, len(rec.Addresses), res.Email, res.Score)
+}
+
+func appendUnique(list []string, items ...string) []string {
+	for _, it := range items {
+		dup := false
+		for _, x := range list {
+			if x == it {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			list = append(list, it)
+		}
+	}
+	return list
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// Store mit Platten-Cache im Temp-Verzeichnis; der globale Index wird danach zurückgesetzt.
+func testDocStore(t *testing.T) {
+	t.Helper()
+	oldCfg, oldStore := runCfg, docStore
+	t.Cleanup(func() { runCfg, docStore = oldCfg, oldStore })
+	runCfg.Offline = false
+	runCfg.CacheEnabled, runCfg.CacheDir = true, t.TempDir()
+	docStore = &docStoreIndex{bySHA: map[string]*docRecord{}, byURL: map[string]string{}, byAuthor: map[string][]string{}}
+}
+
+// Harvest eines Papers: Autorenblock mit zwei Autoren, Adressen in Fundreihenfolge.
+func testPaperHarvest(complete bool) docHarvest {
+	return docHarvest{
+		Addresses: []pdfCandidate{
+			{Email: "jane.doe@uni-x.de", Via: pdfViaText},
+			{Email: "john.smith@uni-y.de", Via: pdfViaText},
+			{Email: "office@uni-x.de", Via: pdfViaText},
+		},
+		Authors: []string{"Jane Doe"},
+		Block: &pdfAuthorBlock{
+			Authors: []pdfAuthor{{Name: "Jane Doe", First: "jane", Last: "doe"}, {Name: "John Smith", First: "john", Last: "smith"}},
+			Emails:  []string{"jane.doe@uni-x.de", "john.smith@uni-y.de"},
+			Assign:  map[string]int{"jane.doe@uni-x.de": 0, "john.smith@uni-y.de": 1},
+		},
+		Complete: complete,
+	}
+}
+
+func TestDocStorePutMerge(t *testing.T) {
+	testDocStore(t)
+	const sha = "ab12"
+
+	first := docHarvest{Addresses: []pdfCandidate{{Email: "jane.doe@uni-x.de", Via: pdfViaMetadata}}, Authors: []string{"Jane Doe"}}
+	docStorePut(sha, "https://uni-x.de/paper.pdf", docTypePDF, first)
+	rec := docStorePut(sha, "https://mirror.org/paper.pdf", docTypePDF, testPaperHarvest(true))
+
+	if !rec.Complete {
+		t.Error("Complete = false nach vollständigem zweitem Scan")
+	}
+	if want := []string{"https://uni-x.de/paper.pdf", "https://mirror.org/paper.pdf"}; !reflect.DeepEqual(rec.URLs, want) {
+		t.Errorf("URLs = %v, erwartet %v", rec.URLs, want)
+	}
+	var emails []string
+	for _, a := range rec.Addresses {
+		emails = append(emails, a.Email)
+	}
+	if want := []string{"jane.doe@uni-x.de", "john.smith@uni-y.de", "office@uni-x.de"}; !reflect.DeepEqual(emails, want) {
+		t.Errorf("Adressen = %v, erwartet %v", emails, want)
+	}
+	if rec.Addresses[0].Via != pdfViaMetadata {
+		t.Errorf("erste Herkunft überschrieben: %q", rec.Addresses[0].Via)
+	}
+	if rec.Block == nil {
+		t.Fatal("Autorenblock fehlt")
+	}
+
+	// neuer Lauf: nur der Platten-Cache kennt das Dokument
+	docStore = &docStoreIndex{bySHA: map[string]*docRecord{}, byURL: map[string]string{}, byAuthor: map[string][]string{}}
+	got := docStoreByURL("https://mirror.org/paper.pdf")
+	if got == nil || got.SHA256 != sha || !reflect.DeepEqual(got.docHarvest, rec.docHarvest) {
+		t.Fatalf("docStoreByURL nach Neustart = %+v", got)
+	}
+}
+
+// Ein Co-Autor wird aus dem gespeicherten Harvest beantwortet, ohne das Dokument neu zu scannen.
+func TestDocStoreCoAuthor(t *testing.T) {
+	testDocStore(t)
+	docStorePut("cd34", "https://uni-x.de/paper.pdf", docTypePDF, testPaperHarvest(true))
+
+	recs := docStoreForPerson("John Smith")
+	if len(recs) != 1 {
+		t.Fatalf("docStoreForPerson = %d Records, erwartet 1", len(recs))
+	}
+	res := recs[0].rescore("John Smith")
+	if res.Email != "john.smith@uni-y.de" || !recs[0].usable(res) {
+		t.Errorf("rescore = %s/%d, usable = %v", res.Email, res.Score, recs[0].usable(res))
+	}
+	if len(docStoreForPerson("Max Muster")) != 0 {
+		t.Error("Record für eine fremde Person gefunden")
+	}
+}
+
+func TestDocRecordUsable(t *testing.T) {
+	testDocStore(t)
+	rec := docStorePut("ef56", "https://uni-x.de/early.pdf", docTypePDF, testPaperHarvest(false))
+
+	// Early-Exit-Scan: der beste Treffer stimmt, reicht aber nur mit sicherem Score
+	res := rec.rescore("Jane Doe")
+	if res.Email != "jane.doe@uni-x.de" {
+		t.Errorf("rescore(Jane Doe) = %s/%d", res.Email, res.Score)
+	}
+	if got, want := rec.usable(res), res.Score >= highConfidenceCutoff; got != want {
+		t.Errorf("usable(%d) = %v, erwartet %v", res.Score, got, want)
+	}
+	if !rec.usable(pdfScanResult{Email: "jane.doe@uni-x.de", Score: highConfidenceCutoff}) {
+		t.Error("sicherer Treffer aus unvollständigem Scan gilt nicht als Antwort")
+	}
+	if res := rec.rescore("Erika Mustermann"); rec.usable(res) {
+		t.Errorf("Erika Mustermann: %s/%d gilt als beantwortet", res.Email, res.Score)
+	}
+}
+
+// Indizierung ergänzt denselben Record parallel, während Leser ihn bewerten (go test -race).
+func TestDocStoreConcurrent(t *testing.T) {
+	testDocStore(t)
+	const sha = "9a9a"
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			h := testPaperHarvest(i%2 == 0)
+			h.Addresses = append(h.Addresses, pdfCandidate{Email: fmt.Sprintf("author%d@uni-x.de", i), Via: pdfViaText})
+			docStorePut(sha, fmt.Sprintf("https://uni-x.de/copy%d.pdf", i), docTypePDF, h)
+		}(i)
+		go func() {
+			defer wg.Done()
+			if rec := docStoreBySHA(sha); rec != nil && rec.Complete {
+				_ = rec.rescore("John Smith")
+				_ = len(rec.URLs) + len(rec.Addresses)
+			}
+		}()
+	}
+	wg.Wait()
+	if rec := docStoreBySHA(sha); rec == nil || len(rec.URLs) != 8 || len(rec.Addresses) != 3+8 {
+		t.Errorf("Record nach parallelem Ablegen = %+v", rec)
+	}
+}
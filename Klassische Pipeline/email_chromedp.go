@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"github.com/PuerkitoBio/goquery"
 	"github.com/chromedp/chromedp"
 	"strings"
 	"time"
@@ -13,55 +15,75 @@ import (
 // (inkl. symbolischer Schreibweise) und bewertet sie mit getScoreOrgGeneral.
 // 'name' wird als "Name + Organisation" interpretiert (z. B. "Christos Cassandras Boston University").
 func ExtractEmailFromURL(url string, name string) (string, int, error) {
+	res, err := extractEmailRendered(url, name)
+	return res.Email, res.Score, err
+}
+
+// renderedResult ist das Ergebnis eines Headless-Abrufs.
+type renderedResult struct {
+	Email string
+	Score int
+	Via   string // URL des Frames bzw. der XHR/fetch-Antwort, aus der die Adresse stammt ("" = Seite selbst)
+}
+
+// extractEmailRendered wie ExtractEmailFromURL, liefert aber zusätzlich Frame-/API-Quelle.
+func extractEmailRendered(url string, name string) (renderedResult, error) {
 	start := time.Now()
+	var res renderedResult
+
+	if err := robotsCheck(url, "Chromedp"); err != nil {
+		return res, err
+	}
 
 	cleanName := cleanQueryNoise(name)
 	firstName, middleName, lastName, org := splitNameAndOrgNoLists(cleanName)
 
-	// ChromeDP Context
-	ctx, cancel := chromedp.NewContext(context.Background())
-	defer cancel()
-	ctx, cancel = context.WithTimeout(ctx, 12*time.Second)
-	defer cancel()
-
-	// 1) mailto:-Links einsammeln
-	var attrs []map[string]string
-	err := chromedp.Run(ctx,
-		chromedp.Navigate(url),
-		chromedp.WaitReady("body"),
-		chromedp.AttributesAll(`a[href^="mailto:"]`, &attrs, chromedp.ByQueryAll),
-	)
-	if err != nil {
-		// nicht fatal – wir versuchen trotzdem Body/HTML
+	// 0) gerendertes DOM aus dem Cache (spart den Browserstart)
+	snap, cached := loadRenderedSnapshot(url)
+	if !cached {
+		if runCfg.Offline {
+			return res, errOfflineMiss
+		}
+		snap = renderSnapshot(url)
+		saveRenderedSnapshot(url, snap)
 	}
-
-	// 2) Body-Text & Body-HTML holen (für normale & symbolische E-Mails)
-	var bodyText, bodyHTML string
-	_ = chromedp.Run(ctx,
-		chromedp.Text("body", &bodyText, chromedp.NodeVisible, chromedp.ByQuery),
-		chromedp.OuterHTML("body", &bodyHTML, chromedp.ByQuery),
-	)
+	bodyText, bodyHTML, docHTML := snap.BodyText, snap.BodyHTML, snap.DocHTML
 
 	highestScore := -1
 	bestEmail := ""
+	bestSource := ""
 
-	checkCandidate := func(raw string, source string) {
-		mail := extractEmailFromText(raw)
-		if mail == "" {
-			return
-		}
-		score := getScoreOrgGeneral(strings.ToLower(mail), firstName, middleName, lastName, org)
+	addScored := func(mail string, score int, source string) {
 		if score > highestScore {
 			highestScore = score
 			bestEmail = mail
+			bestSource = source
 			// Optionales Debug:
 			// fmt.Printf("  [%s] %s (score=%d)\n", source, mail, score)
 		}
 	}
+	checkCandidate := func(raw string, source string) {
+		mail := extractEmailFromText(raw)
+		if mail == "" {
+			return
+		}
+		addScored(mail, getScoreOrgGeneral(strings.ToLower(mail), firstName, middleName, lastName, org), source)
+	}
+
+	// --- 2.0 strukturierte Daten (JSON-LD, Microdata, RDFa, hCard, .vcf) ---
+	if doc, derr := goquery.NewDocumentFromReader(strings.NewReader(docHTML)); derr == nil && docHTML != "" {
+		persons := extractStructuredPersons(doc.Selection)
+		linked, _ := fetchLinkedVCards(linkedVCards(doc.Selection, url))
+		for _, p := range append(persons, linked...) {
+			if mail, score := scoreStructuredPerson(p, firstName, middleName, lastName, org); mail != "" {
+				addScored(mail, score, "structured-"+p.Origin)
+			}
+		}
+	}
 
 	// --- 2.1 mailto: ---
-	for _, m := range attrs {
-		if href, ok := m["href"]; ok && strings.HasPrefix(strings.ToLower(href), "mailto:") {
+	for _, href := range snap.Mailtos {
+		if strings.HasPrefix(strings.ToLower(href), "mailto:") {
 			addr := extractAddressFromMailto(href)
 			checkCandidate(addr, "mailto")
 		}
@@ -71,6 +93,10 @@ func ExtractEmailFromURL(url string, name string) (string, int, error) {
 	for _, m := range reEmailNormal.FindAllString(bodyText, -1) {
 		checkCandidate(m, "text-normal")
 	}
+	// - 2.2b sichtbarer Text: gruppierte Adressen ({a, b}@uni.de) ---
+	for _, em := range expandGroupedEmails(bodyText) {
+		checkCandidate(em, "text-grouped")
+	}
 	// - 2.3 sichtbarer Text: symbolische E-Mails (strikt) ---
 	for _, em := range extractSymbolicEmailsStrict(bodyText, org) {
 		checkCandidate(em, "text-symbolic")
@@ -85,12 +111,143 @@ func ExtractEmailFromURL(url string, name string) (string, int, error) {
 		checkCandidate(em, "html-symbolic")
 	}
 
+	// - 2.6 XHR/fetch-Antworten derselben Site (Quelle = API-URL) ---
+	for _, r := range snap.APIResponses {
+		text := apiResponseText(r.Body)
+		src := "api:" + r.URL
+		for _, m := range reEmailNormal.FindAllString(text, -1) {
+			checkCandidate(m, src)
+		}
+		for _, em := range expandGroupedEmails(text) {
+			checkCandidate(em, src)
+		}
+		for _, em := range extractSymbolicEmailsStrict(text, org) {
+			checkCandidate(em, src)
+		}
+	}
+
+	// - 2.7 Shadow Roots & iframes (Quelle = Frame-URL) ---
+	for _, f := range snap.Frames {
+		src := "frame:" + f.URL
+		for _, href := range f.Mailtos {
+			checkCandidate(extractAddressFromMailto(href), src)
+		}
+		for _, m := range reEmailNormal.FindAllString(f.Text+"\n"+f.HTML, -1) {
+			checkCandidate(m, src)
+		}
+		for _, em := range expandGroupedEmails(f.Text) {
+			checkCandidate(em, src)
+		}
+		for _, em := range extractSymbolicEmailsStrict(f.Text, org) {
+			checkCandidate(em, src)
+		}
+	}
+
 	duration := time.Since(start)
 	if bestEmail == "" {
-		return "", 0, fmt.Errorf("keine gültige Adresse extrahiert (%.2fs)", duration.Seconds())
+		return res, fmt.Errorf("keine gültige Adresse extrahiert (%.2fs)", duration.Seconds())
 	}
 	fmt.Printf("⏱️ [Chromedp] %s: %.2fs\n", name, duration.Seconds())
-	return bestEmail, highestScore, nil
+	res.Email, res.Score = bestEmail, highestScore
+	if via, ok := strings.CutPrefix(bestSource, "api:"); ok {
+		res.Via = via
+		fmt.Printf("📡 [Chromedp] %s aus API-Antwort %s\n", bestEmail, via)
+	} else if via, ok := strings.CutPrefix(bestSource, "frame:"); ok && via != url {
+		res.Via = via
+		fmt.Printf("🪟 [Chromedp] %s aus Frame %s\n", bestEmail, via)
+	}
+	return res, nil
+}
+
+// ---------------- Rendern & Snapshot-Cache ----------------
+
+// renderedSnapshot ist das, was der Extraktor von einer gerenderten Seite braucht.
+type renderedSnapshot struct {
+	BodyText string   `json:"body_text"`
+	BodyHTML string   `json:"body_html"`
+	DocHTML  string   `json:"doc_html"`
+	Mailtos  []string `json:"mailtos"`
+
+	APIResponses []apiResponse   `json:"api_responses,omitempty"` // XHR/fetch derselben Site
+	Frames       []frameSnapshot `json:"frames,omitempty"`        // Shadow Roots & iframes
+}
+
+// renderTimeout: Laden + Interaktionen je Seite.
+const renderTimeout = 20 * time.Second
+
+// renderSnapshot lädt die Seite im Headless-Browser und liest Text, HTML und mailto:-Links.
+func renderSnapshot(url string) renderedSnapshot {
+	var snap renderedSnapshot
+
+	// Tab aus dem gemeinsamen Browser-Pool
+	err := headlessPool().withTab(renderTimeout, func(ctx context.Context) error {
+		net := trackNetwork(ctx)
+		api := captureAPIResponses(ctx, url)
+		blocker, berr := startResourceBlocking(ctx)
+		if berr != nil {
+			return berr
+		}
+		defer blocker.stop(ctx)
+
+		loadStart := time.Now()
+		err := chromedp.Run(ctx,
+			chromedp.Navigate(url),
+			chromedp.WaitReady("body"),
+		)
+		// nicht fatal – wir versuchen trotzdem Body/HTML
+		fmt.Printf("⚡ [Chromedp] %s: %s\n", url, blocker.summary(time.Since(loadStart)))
+
+		// 1) Consent, Reveal-Buttons, Scrollen, Netzwerkruhe
+		if len(runCfg.InteractSteps) > 0 && err == nil {
+			if done := runInteractions(ctx, runCfg.InteractSteps, net); done != "" {
+				fmt.Printf("🖱️ [Chromedp] %s: %s\n", url, done)
+			}
+		}
+
+		// 2) mailto:-Links einsammeln
+		var attrs []map[string]string
+		_ = chromedp.Run(ctx, chromedp.AttributesAll(`a[href^="mailto:"]`, &attrs, chromedp.ByQueryAll, chromedp.AtLeast(0)))
+		for _, m := range attrs {
+			if href, ok := m["href"]; ok {
+				snap.Mailtos = append(snap.Mailtos, href)
+			}
+		}
+
+		// 3) Body-Text & Body-HTML holen (für normale & symbolische E-Mails), API-Antworten abholen
+		_ = chromedp.Run(ctx,
+			api.collect(&snap.APIResponses),
+			chromedp.Text("body", &snap.BodyText, chromedp.NodeVisible, chromedp.ByQuery),
+			chromedp.OuterHTML("body", &snap.BodyHTML, chromedp.ByQuery),
+			chromedp.OuterHTML("html", &snap.DocHTML, chromedp.ByQuery), // inkl. <head> (JSON-LD)
+		)
+
+		// 4) Shadow Roots & Frames (lädt Cross-Origin-Frames zuletzt im selben Tab)
+		snap.Frames = collectFrames(ctx, url)
+		return err
+	})
+	if err != nil {
+		fmt.Printf("⚠️ [Chromedp] %s: %v\n", url, err)
+	}
+	return snap
+}
+
+func loadRenderedSnapshot(url string) (renderedSnapshot, bool) {
+	var snap renderedSnapshot
+	e, ok := cacheGet(cacheKindRendered, url)
+	if !ok || json.Unmarshal(e.Body, &snap) != nil {
+		return snap, false
+	}
+	return snap, true
+}
+
+// saveRenderedSnapshot cacht nur Seiten, die tatsächlich etwas geliefert haben.
+func saveRenderedSnapshot(url string, snap renderedSnapshot) {
+	if snap.DocHTML == "" && snap.BodyText == "" {
+		return
+	}
+	if b, err := json.Marshal(snap); err == nil {
+		cachePut(cacheKindRendered, url, 200, "application/json", "", b)
+	}
 }
 
 // ---------------- Extraktion & Validierung ----------------
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"github.com/gocolly/colly"
+	neturl "net/url"
 	"regexp"
 	"strings"
 	"time"
@@ -11,89 +13,176 @@ import (
 // ExtractEmailWithColly besucht eine URL, extrahiert Kandidaten und bewertet mit getScoreOrgGeneral.
 // Der Parameter 'name' wird als "Name + Organisation" interpretiert.
 func ExtractEmailWithColly(url string, name string) (string, int, error) {
+	res, err := extractEmailStatic(url, name)
+	return res.Email, res.Score, err
+}
+
+// staticResult ist das Ergebnis eines statischen Abrufs (ohne Browser).
+type staticResult struct {
+	Email    string
+	Score    int
+	Page     string // Seite, auf der die beste Adresse gefunden wurde (ggf. per Crawl)
+	NeedsJS  bool   // Landing-Page braucht vermutlich JavaScript → Headless-Eskalation
+	JSReason string
+}
+
+// extractEmailStatic wie ExtractEmailWithColly, folgt aber zusätzlich (fokussierter Crawl,
+// siehe crawl.go) Kontakt-/Profil-Links derselben Site, merkt sich die Fundseite und
+// prüft, ob die Landing-Page JavaScript braucht (siehe fetch.go).
+func extractEmailStatic(url string, name string) (staticResult, error) {
 	start := time.Now()
+	var res staticResult
 	c := colly.NewCollector(
 		colly.UserAgent("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"),
 	)
 
-	c.Limit(&colly.LimitRule{
-		DomainGlob:  "*",
-		Delay:       2 * time.Second,
-		RandomDelay: 1 * time.Second,
+	// gemeinsamer HTTP-Cache (offline: nur Cache)
+	c.WithTransport(cacheTransport(cacheKindPage))
+	if !runCfg.Offline {
+		c.Limit(&colly.LimitRule{
+			DomainGlob:  "*",
+			Delay:       2 * time.Second,
+			RandomDelay: 1 * time.Second,
+		})
+	}
+
+	// robots.txt-Policy für jede Anfrage (auch Crawl-Links)
+	c.OnRequest(func(r *colly.Request) {
+		if err := robotsCheck(r.URL.String(), "Colly"); err != nil {
+			r.Abort()
+		}
+	})
+
+	// JS-Bedarf nur für die Landing-Page prüfen (nicht für Crawl-Seiten; Colly
+	// vergibt bei c.Visit immer Tiefe 1, daher eigener Schalter)
+	landing := true
+	c.OnResponse(func(r *colly.Response) {
+		ct := strings.ToLower(r.Headers.Get("Content-Type"))
+		if landing && !res.NeedsJS && (ct == "" || strings.Contains(ct, "html")) {
+			res.NeedsJS, res.JSReason = needsJavaScript(string(r.Body))
+		}
 	})
 
-	// generisches E-Mail-Muster
-	emailPattern := regexp.MustCompile(`(?i)\b[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,}\b`)
-	allEmails := make(map[string]bool)
-	var bestEmail string
+	allEmails := make(map[string]string) // E-Mail → Seite
+	var bestEmail, bestPage string
 	highestScore := -1
 	// ——— Name + Organisation heuristisch aus "name" ableiten (robust gg. Suchzusätze) ———
 	firstName, middleName, lastName, org := splitNameAndOrg(cleanQueryNoise(name))
 
-	checkAndAddEmail := func(raw string) {
-		mail := extractEmailFromText(raw) // <— statt sanitizeEmail(raw)
-		if mail == "" {
-			return
+	addScored := func(mail string, score int, page string) {
+		if _, ok := allEmails[mail]; !ok {
+			allEmails[mail] = page
 		}
-		score := getScoreOrgGeneral(strings.ToLower(mail), firstName, middleName, lastName, org)
-
-		allEmails[mail] = true
 		if score > highestScore {
 			bestEmail = mail
+			bestPage = page
 			highestScore = score
 		}
 	}
+	checkAndAddEmail := func(raw string, page string) {
+		mail := extractEmailFromText(raw) // <— statt sanitizeEmail(raw)
+		if mail == "" {
+			return
+		}
+		addScored(mail, getScoreOrgGeneral(strings.ToLower(mail), firstName, middleName, lastName, org), page)
+	}
 
-	c.OnHTML("body", func(e *colly.HTMLElement) {
-		// 1) Normale E-Mail-Erkennung im sichtbaren Text
-		for _, match := range emailPattern.FindAllString(e.Text, -1) {
-			checkAndAddEmail(match)
+	// Crawl-Zustand: gesammelte Kontakt-/Profil-Links der aktuellen Ebene
+	var crawlNext []crawlLink
+	crawlDepth := 0
+	c.OnHTML("a[href]", func(e *colly.HTMLElement) {
+		if crawlDepth >= runCfg.CrawlDepth {
+			return
 		}
-		// 1b) SYMBOLISCHE Erkennung im sichtbaren Text
-		for _, em := range extractSymbolicEmailsStrict(e.Text, org) {
-			checkAndAddEmail(em)
+		page := e.Request.URL.String()
+		link := e.Request.AbsoluteURL(e.Attr("href"))
+		if prio := profileLinkPriority(e.Text, link, page, firstName, lastName); prio > 0 {
+			crawlNext = append(crawlNext, crawlLink{URL: link, Prio: prio})
 		}
+	})
 
-		// 2) Fragmentierte HTML-Varianten
-		rawHTML, _ := e.DOM.Html()
-		fragmentedEmailPattern := regexp.MustCompile(`([a-zA-Z0-9._%+\-]+)<span[^>]*?>.*?</span>@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`)
-		for _, match := range fragmentedEmailPattern.FindAllString(rawHTML, -1) {
-			stripped := stripHTMLTags(match)
-			stripped = strings.ReplaceAll(stripped, "\n", "")
-			checkAndAddEmail(stripped)
+	// 0) Strukturierte Daten (JSON-LD, Microdata, RDFa, hCard, .vcf) – steht oft im <head>
+	// verlinkte .vcf-Dateien erst nach dem Crawl abrufen (nicht im Callback)
+	var vcards []vcardLink
+	c.OnHTML("html", func(e *colly.HTMLElement) {
+		for _, p := range extractStructuredPersons(e.DOM) {
+			if mail, score := scoreStructuredPerson(p, firstName, middleName, lastName, org); mail != "" {
+				addScored(mail, score, e.Request.URL.String())
+			}
 		}
+		vcards = append(vcards, linkedVCards(e.DOM, e.Request.URL.String())...)
+	})
 
-		// 3) MSO/Alternative
-		altEmailPattern := regexp.MustCompile(`(?i)([a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,})`)
-		for _, match := range altEmailPattern.FindAllString(rawHTML, -1) {
-			checkAndAddEmail(match)
-		}
-		// 3b) SYMBOLISCHE Erkennung im HTML (falls Text nicht gereicht hat)
-		for _, em := range extractSymbolicEmailsStrict(rawHTML, org) {
-			checkAndAddEmail(em)
+	c.OnHTML("body", func(e *colly.HTMLElement) {
+		page := e.Request.URL.String()
+		rawHTML, _ := e.DOM.Html()
+		for _, match := range htmlEmailCandidates(e.Text, rawHTML, org) {
+			checkAndAddEmail(match, page)
 		}
 	})
 
 	// 4) mailto:-Links
 	c.OnHTML("a[href^='mailto:']", func(e *colly.HTMLElement) {
+		page := e.Request.URL.String()
 		text := e.Text
 		href := strings.TrimPrefix(e.Attr("href"), "mailto:")
 		for _, src := range []string{href, text} {
 			// nicht regexpen, sondern robust extrahieren:
 			if mail := extractEmailFromText(src); mail != "" {
-				checkAndAddEmail(mail)
+				checkAndAddEmail(mail, page)
 			}
 		}
 	})
 
 	if err := c.Visit(url); err != nil {
-		return "", 0, err
+		// nur Netz-/Timeout-Fehler an den Browser geben; HTTP-Status (404, 403 …),
+		// robots.txt und Offline-Cache-Miss helfen dort auch nicht weiter
+		var netErr *neturl.Error
+		if errors.As(err, &netErr) && !errors.Is(err, errOfflineMiss) {
+			res.NeedsJS, res.JSReason = true, "statischer Abruf fehlgeschlagen"
+		}
+		return res, err
+	}
+	landing = false
+
+	// Fokussierter Crawl: Ebene für Ebene, beste Links zuerst, bis Budget erschöpft
+	// oder die Adresse bereits sicher ist.
+	visited := map[string]bool{strings.TrimSuffix(url, "/"): true}
+	extraPages := 0
+	for crawlDepth < runCfg.CrawlDepth && extraPages < runCfg.CrawlMaxPages && highestScore < hardAcceptScore {
+		frontier := nextCrawlFrontier(crawlNext, visited)
+		crawlNext = nil
+		crawlDepth++
+		if len(frontier) == 0 {
+			break
+		}
+		for _, l := range frontier {
+			if extraPages >= runCfg.CrawlMaxPages || highestScore >= hardAcceptScore {
+				break
+			}
+			visited[l.URL] = true
+			if err := c.Visit(l.URL); err != nil {
+				continue
+			}
+			extraPages++
+			fmt.Printf("🕸️ [Crawl] %s (prio %d)\n", l.URL, l.Prio)
+		}
+	}
+
+	if highestScore < hardAcceptScore {
+		persons, pages := fetchLinkedVCards(vcards)
+		for i, p := range persons {
+			if mail, score := scoreStructuredPerson(p, firstName, middleName, lastName, org); mail != "" {
+				addScored(mail, score, pages[i])
+			}
+		}
 	}
 
 	// Fallback: erste valide Adresse
 	if bestEmail == "" {
-		for email := range allEmails {
+		for email, page := range allEmails {
 			bestEmail = email
+			bestPage = page
 			highestScore = 0
 			break
 		}
@@ -102,44 +191,45 @@ func ExtractEmailWithColly(url string, name string) (string, int, error) {
 	fmt.Printf("⏱️ [Colly] %s: %.2fs\n", name, time.Since(start).Seconds())
 
 	if bestEmail == "" {
-		return "", 0, fmt.Errorf("keine E-Mail extrahiert")
+		return res, fmt.Errorf("keine E-Mail extrahiert")
 	}
 
-	return bestEmail, highestScore, nil
+	res.Email, res.Score, res.Page = bestEmail, highestScore, bestPage
+	return res, nil
 }
 
-// NEU: symbolische E-Mails aus freiem Text extrahieren (at/dot-Varianten)
-func extractSymbolicEmails(text string) []string {
-	// etwas „robustere“ Erkennung: username [at|@] domain [dot|.] tld
-	// wir verlangen mind. EINEN Punkt nach dem Zusammensetzen
-	reSym := regexp.MustCompile(`(?i)([a-z0-9._+\-]{1,64})\s*(?:\(|\[)?\s*at\s*(?:\)|\])?\s*([a-z0-9.\-\s\[\]\(\)]{1,200})`)
-	cands := []string{}
-	matches := reSym.FindAllStringSubmatch(text, -1)
-	for _, m := range matches {
-		local := strings.ToLower(strings.TrimSpace(m[1]))
-		rawDomain := strings.ToLower(m[2])
-		// " dot " / "(dot)" / "[dot]" / reale Punkte → echte Punkte, Whitespaces raus
-		d := rawDomain
-		replacements := []string{" (dot) ", " dot ", "[dot]", "(dot)", " dot", "dot "}
-		for _, r := range replacements {
-			d = strings.ReplaceAll(d, r, ".")
-		}
-		d = strings.ReplaceAll(d, " ", "")
-		d = strings.ReplaceAll(d, "[.]", ".")
-		d = strings.ReplaceAll(d, "(.)", ".")
-		// minimal säubern (mehrfachpunkte reduzieren)
-		d = regexp.MustCompile(`\.{2,}`).ReplaceAllString(d, ".")
-
-		// harte Filter: Local & Domain plausibel?
-		if local == "" || len(local) > 64 || !regexp.MustCompile(`^[a-z0-9._+\-]+$`).MatchString(local) {
-			continue
-		}
-		if !validDomain(d) {
-			continue
-		}
-		cands = append(cands, local+"@"+d)
+var (
+	reHTMLEmail      = regexp.MustCompile(`(?i)\b[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,}\b`)
+	reHTMLFragmented = regexp.MustCompile(`([a-zA-Z0-9._%+\-]+)<span[^>]*?>.*?</span>@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`)
+	reHTMLAltEmail   = regexp.MustCompile(`(?i)([a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,})`)
+)
+
+// htmlEmailCandidates: Rohkandidaten aus sichtbarem Text und HTML des <body> (auch für
+// lokal gespeicherte Seiten, siehe corpus.go). org steuert die symbolische Erkennung.
+func htmlEmailCandidates(text, rawHTML, org string) []string {
+	var out []string
+	// 1) Normale E-Mail-Erkennung im sichtbaren Text
+	out = append(out, reHTMLEmail.FindAllString(text, -1)...)
+	// 1a) gruppierte Adressen ({a, b}@uni.de) → ein Kandidat je Local-Part
+	out = append(out, expandGroupedEmails(text)...)
+	// 1b) SYMBOLISCHE Erkennung im sichtbaren Text
+	out = append(out, extractSymbolicEmailsStrict(text, org)...)
+
+	// 2) Fragmentierte HTML-Varianten
+	for _, match := range reHTMLFragmented.FindAllString(rawHTML, -1) {
+		stripped := stripHTMLTags(match)
+		out = append(out, strings.ReplaceAll(stripped, "\n", ""))
 	}
-	return cands
+	// 3) MSO/Alternative
+	out = append(out, reHTMLAltEmail.FindAllString(rawHTML, -1)...)
+	// 3b) SYMBOLISCHE Erkennung im HTML (falls Text nicht gereicht hat)
+	out = append(out, extractSymbolicEmailsStrict(rawHTML, org)...)
+	return out
+}
+
+// NEU: symbolische E-Mails aus freiem Text extrahieren (at/dot-Varianten, mehrsprachig)
+func extractSymbolicEmails(text string) []string {
+	return extractObfuscatedEmails(text)
 }
 
 func stripHTMLTags(input string) string {
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// =================== Belege für akzeptierte Treffer ===================
+//
+// Mit --evidence-dir wird für jede akzeptierte Adresse die Quellseite im
+// Headless-Browser geöffnet, das Element mit der Adresse markiert und ein
+// ganzseitiger Screenshot plus DOM-Snapshot abgelegt. Das Verzeichnis
+// steht in der Ausgabe-CSV (3. Spalte). PDF-Quellen, Offline-Läufe und
+// lokale Korpus-Seiten bekommen nur die Metadaten bzw. das (gecachte) HTML.
+
+const (
+	evidenceTimeout = 25 * time.Second
+	evidenceQuality = 80 // JPEG-Qualität des Screenshots
+)
+
+var reEvidenceSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+// evidenceMeta wird als evidence.json neben Screenshot und DOM abgelegt.
+type evidenceMeta struct {
+	Name        string    `json:"name"`
+	Email       string    `json:"email"`
+	Source      string    `json:"source"`
+	Mode        string    `json:"mode"`
+	Pick        string    `json:"pick,omitempty"` // finale Auswahl statt Early-Accept
+	Via         string    `json:"via,omitempty"`  // Herkunft im Dokument, z. B. repaired:hyphen
+	Captured    time.Time `json:"captured"`
+	Highlighted bool      `json:"highlighted"` // Element mit der Adresse gefunden und markiert
+	Screenshot  string    `json:"screenshot,omitempty"`
+	DOM         string    `json:"dom,omitempty"`
+	RenderedDOM string    `json:"rendered_dom,omitempty"` // DOM aus der Extraktion, falls der neue Tab die Adresse nicht zeigt
+	Note        string    `json:"note,omitempty"`
+}
+
+// captureEvidence legt die Belege ab und liefert das Verzeichnis ("" = aus/fehlgeschlagen).
+func captureEvidence(row ResultRow) string {
+	if runCfg.EvidenceDir == "" || row.Email == "" || row.Source == "" {
+		return ""
+	}
+	dir := filepath.Join(runCfg.EvidenceDir, evidenceDirName(row))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		fmt.Printf("⚠️ Beleg-Verzeichnis %s: %v\n", dir, err)
+		return ""
+	}
+	meta := evidenceMeta{Name: row.Name, Email: row.Email, Source: row.Source, Mode: row.Mode, Pick: row.Pick, Via: row.Via, Captured: time.Now()}
+
+	switch {
+	case row.Mode == fetchModePDF || row.Mode == fetchModeDocument:
+		meta.Note = "Dokument-Quelle: kein Screenshot"
+	case row.Mode == fetchModeLocal:
+		meta.Note = "lokale Datei: Kopie als DOM, kein Screenshot"
+		if html, err := os.ReadFile(row.Source); err == nil && os.WriteFile(filepath.Join(dir, "dom.html"), html, 0o644) == nil {
+			meta.DOM = "dom.html"
+		}
+	case runCfg.Offline:
+		meta.Note = "offline: DOM aus dem Cache, kein Screenshot"
+		if html := cachedHTML(row.Source); html != "" && os.WriteFile(filepath.Join(dir, "dom.html"), []byte(html), 0o644) == nil {
+			meta.DOM = "dom.html"
+		}
+	default:
+		if err := robotsCheck(row.Source, "Evidence"); err != nil {
+			meta.Note = err.Error()
+			break
+		}
+		shot, dom, found, err := renderEvidence(row.Source, row.Email)
+		if err != nil {
+			meta.Note = err.Error()
+		}
+		meta.Highlighted = found
+		if len(shot) > 0 && os.WriteFile(filepath.Join(dir, "screenshot.jpg"), shot, 0o644) == nil {
+			meta.Screenshot = "screenshot.jpg"
+		}
+		if dom != "" && os.WriteFile(filepath.Join(dir, "dom.html"), []byte(dom), 0o644) == nil {
+			meta.DOM = "dom.html"
+		}
+		if snap, ok := loadRenderedSnapshot(row.Source); !found && ok &&
+			strings.Contains(strings.ToLower(snap.DocHTML), strings.ToLower(row.Email)) &&
+			os.WriteFile(filepath.Join(dir, "rendered.html"), []byte(snap.DocHTML), 0o644) == nil {
+			meta.RenderedDOM = "rendered.html"
+		}
+	}
+
+	if b, err := json.MarshalIndent(meta, "", "  "); err == nil {
+		_ = os.WriteFile(filepath.Join(dir, "evidence.json"), b, 0o644)
+	}
+	fmt.Printf("📸 Beleg: %s → %s\n", row.Email, dir)
+	return dir
+}
+
+// renderEvidence lädt die Seite in einem Pool-Tab, wiederholt die Interaktionen der
+// Extraktion (Consent, Reveal … – sonst fehlt die Adresse oft), markiert die Adresse
+// und macht Screenshot + DOM.
+func renderEvidence(pageURL, email string) (shot []byte, dom string, found bool, err error) {
+	err = headlessPool().withTab(evidenceTimeout, func(ctx context.Context) error {
+		net := trackNetwork(ctx)
+		if err := chromedp.Run(ctx,
+			chromedp.Navigate(pageURL),
+			chromedp.WaitReady("body"),
+		); err != nil {
+			return err
+		}
+		if len(runCfg.InteractSteps) > 0 {
+			runInteractions(ctx, runCfg.InteractSteps, net)
+		}
+		_ = chromedp.Run(ctx, chromedp.Evaluate(jsHighlightEmail(email), &found))
+		return chromedp.Run(ctx,
+			chromedp.FullScreenshot(&shot, evidenceQuality),
+			chromedp.OuterHTML("html", &dom, chromedp.ByQuery),
+		)
+	})
+	return shot, dom, found, err
+}
+
+// evidenceDirName: „christos-cassandras-1a2b3c4d“ (Hash über Name, Adresse und Quelle).
+func evidenceDirName(row ResultRow) string {
+	slug := strings.Trim(reEvidenceSlug.ReplaceAllString(asciiFold(strings.ToLower(row.Name)), "-"), "-")
+	if len(slug) > 60 {
+		slug = slug[:60]
+	}
+	sum := sha256.Sum256([]byte(row.Name + "\n" + row.Email + "\n" + row.Source))
+	return slug + "-" + hex.EncodeToString(sum[:4])
+}
+
+// cachedHTML: gerendertes DOM oder statische Seite aus dem HTTP-Cache.
+func cachedHTML(pageURL string) string {
+	if snap, ok := loadRenderedSnapshot(pageURL); ok && snap.DocHTML != "" {
+		return snap.DocHTML
+	}
+	if e, ok := cacheGet(cacheKindPage, pageURL); ok {
+		return string(e.Body)
+	}
+	return ""
+}
+
+// jsHighlightEmail markiert mailto-Link bzw. Textknoten mit der Adresse (sonst mit dem Local-Part,
+// z. B. bei „name [at] uni.de“) und scrollt ihn in die Mitte.
+func jsHighlightEmail(email string) string {
+	arg, _ := json.Marshal(strings.ToLower(email))
+	return `((email) => {
+  const local = email.split('@')[0];
+  const mark = (el) => {
+    el.style.outline = '3px solid #e00';
+    el.style.background = '#ff0';
+    el.scrollIntoView({block: 'center'});
+    return true;
+  };
+  for (const a of document.querySelectorAll('a[href^="mailto:" i]')) {
+    if (a.getAttribute('href').toLowerCase().includes(email)) return mark(a);
+  }
+  for (const needle of [email, local]) {
+    if (needle.length < 3) continue;
+    const w = document.createTreeWalker(document.body, NodeFilter.SHOW_TEXT);
+    for (let n = w.nextNode(); n; n = w.nextNode()) {
+      if (n.textContent.toLowerCase().includes(needle) && n.parentElement) return mark(n.parentElement);
+    }
+  }
+  return false;
+})(` + string(arg) + `)`
+}
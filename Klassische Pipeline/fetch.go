@@ -0,0 +1,67 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// =================== Adaptive Eskalation ===================
+//
+// Jede Seite wird zuerst statisch (Colly) geholt. Nur wenn sie erkennbar
+// JavaScript braucht – leerer Body, <noscript>-Hinweis, leerer SPA-Root,
+// Verschleierungs-Skripte – geht sie zusätzlich an den Headless-Browser.
+
+// Abrufmodus einer Seite (wird am Kandidaten/Ergebnis vermerkt).
+const (
+	fetchModeStatic   = "static"
+	fetchModeHeadless = "headless"
+	fetchModePDF      = "pdf"
+	fetchModeDocument = "document" // DOCX, ODT, RTF … (docextract.go)
+	fetchModeLocal    = "local"    // gespeicherte HTML-Seite aus dem lokalen Korpus (corpus.go)
+)
+
+const minStaticTextChars = 200 // weniger sichtbarer Text → vermutlich clientseitig gerendert
+
+var (
+	reNoscriptJS  = regexp.MustCompile(`(?is)<noscript[^>]*>.*?(javascript|enable|aktivieren).*?</noscript>`)
+	reSPARoot     = regexp.MustCompile(`(?is)<div[^>]+id=["'](root|app|__next|__nuxt|ember-app|main-app)["'][^>]*>\s*</div>`)
+	reSPAMarker   = regexp.MustCompile(`(?i)(ng-app|data-server-rendered|window\.__INITIAL_STATE__|<app-root)`)
+	reScriptBlock = regexp.MustCompile(`(?is)<script[^>]*>(.*?)</script>`)
+	reObfScript   = regexp.MustCompile(`(?i)(fromCharCode|unescape\s*\(|atob\s*\(|document\.write|eval\s*\()`)
+	reStyleBlock  = regexp.MustCompile(`(?is)<(script|style|noscript)[^>]*>.*?</(script|style|noscript)>`)
+)
+
+// needsJavaScript bewertet das statische HTML und liefert den Grund für eine Eskalation.
+func needsJavaScript(html string) (bool, string) {
+	if strings.TrimSpace(html) == "" {
+		return true, "leere Antwort"
+	}
+	lh := strings.ToLower(html)
+
+	// Cloudflare & Co. ersetzen Adressen durch Skript-Platzhalter
+	if strings.Contains(lh, "data-cfemail") || strings.Contains(lh, "/cdn-cgi/l/email-protection") {
+		return true, "E-Mail-Schutz (cfemail)"
+	}
+	for _, m := range reScriptBlock.FindAllStringSubmatch(html, -1) {
+		body := strings.ToLower(m[1])
+		if (strings.Contains(body, "mail") || strings.Contains(body, "@")) && reObfScript.MatchString(body) {
+			return true, "Verschleierungs-Skript"
+		}
+	}
+	if reSPARoot.MatchString(html) {
+		return true, "leerer SPA-Root"
+	}
+
+	text := stripHTMLTags(reStyleBlock.ReplaceAllString(html, " "))
+	textLen := len(strings.Join(strings.Fields(text), " "))
+	if textLen < minStaticTextChars {
+		if reNoscriptJS.MatchString(html) {
+			return true, "noscript-Hinweis"
+		}
+		if reSPAMarker.MatchString(html) {
+			return true, "SPA-Framework"
+		}
+		return true, "kaum sichtbarer Text"
+	}
+	return false, ""
+}
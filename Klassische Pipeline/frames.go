@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/chromedp/chromedp"
+)
+
+// =================== Shadow DOM & iframes ===================
+//
+// chromedp.Text("body") sieht weder offene Shadow Roots noch iframes –
+// eingebettete Personal-Widgets und Kontaktformulare bleiben so unsichtbar.
+// Ein Skript läuft deshalb rekursiv durch Shadow Roots und same-origin
+// Frames; Cross-Origin-Frames (für JS gesperrt) werden anschließend im
+// selben Tab direkt geladen. Kandidaten tragen die URL ihres Frames.
+
+const (
+	maxCrossFrames = 3 // Cross-Origin-Frames je Seite
+	maxFrameDepth  = 3
+)
+
+// Typische Einbettungen ohne Kontaktdaten (Karten, Videos, Social, Werbung).
+var frameSkipHosts = []string{
+	"youtube.com", "youtube-nocookie.com", "vimeo.com", "google.com", "googletagmanager.com",
+	"doubleclick.net", "facebook.com", "twitter.com", "x.com", "instagram.com", "linkedin.com",
+	"openstreetmap.org", "recaptcha.net", "hcaptcha.com",
+}
+
+// frameSnapshot: Inhalt eines Frames bzw. der Shadow Roots eines Dokuments.
+type frameSnapshot struct {
+	URL     string   `json:"url"`
+	Text    string   `json:"text"`
+	HTML    string   `json:"html"`
+	Mailtos []string `json:"mailtos,omitempty"`
+}
+
+type frameWalk struct {
+	Frames []frameSnapshot `json:"frames"`
+	Cross  []string        `json:"cross"` // Cross-Origin-Frames (nicht per JS lesbar)
+}
+
+// collectFrames liest Shadow Roots und Frames der geladenen Seite und lädt danach
+// bis zu maxCrossFrames Cross-Origin-Frames im selben Tab (die Seite ist dann verlassen).
+func collectFrames(ctx context.Context, pageURL string) []frameSnapshot {
+	var walk frameWalk
+	if err := chromedp.Run(ctx, chromedp.Evaluate(jsWalkFrames, &walk)); err != nil {
+		return nil
+	}
+	frames := walk.Frames
+
+	loaded := 0
+	seen := map[string]bool{pageURL: true}
+	for _, fu := range walk.Cross {
+		if loaded >= maxCrossFrames || ctx.Err() != nil {
+			break
+		}
+		if seen[fu] || !frameWorthLoading(fu) {
+			continue
+		}
+		seen[fu] = true
+		if ok, _ := robotsAllowed(fu); !ok {
+			continue
+		}
+		loaded++
+		var text, html string
+		var attrs []map[string]string
+		var sub frameWalk
+		err := chromedp.Run(ctx,
+			chromedp.Navigate(fu),
+			chromedp.WaitReady("body"),
+			chromedp.Text("body", &text, chromedp.NodeVisible, chromedp.ByQuery),
+			chromedp.OuterHTML("body", &html, chromedp.ByQuery),
+			chromedp.AttributesAll(`a[href^="mailto:"]`, &attrs, chromedp.ByQueryAll, chromedp.AtLeast(0)),
+			chromedp.Evaluate(jsWalkFrames, &sub),
+		)
+		if err != nil {
+			continue
+		}
+		fsnap := frameSnapshot{URL: fu, Text: text, HTML: html}
+		for _, m := range attrs {
+			if href, ok := m["href"]; ok {
+				fsnap.Mailtos = append(fsnap.Mailtos, href)
+			}
+		}
+		frames = append(frames, fsnap)
+		frames = append(frames, sub.Frames...) // Shadow Roots/Frames im Frame
+	}
+	return frames
+}
+
+func frameWorthLoading(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, h := range frameSkipHosts {
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return false
+		}
+	}
+	return true
+}
+
+// Liefert {frames:[{url,text,html,mailtos}], cross:[src…]}. Für das Top-Dokument nur
+// Shadow-Inhalte (Body-Text/HTML liest der Extraktor ohnehin); about:srcdoc/blank-Frames
+// werden dem Eltern-Dokument zugeordnet.
+var jsWalkFrames = `(() => {
+  const frames = [], cross = [];
+  const maxDepth = ` + strconv.Itoa(maxFrameDepth) + `;
+  const mailtos = (root, acc) => {
+    for (const a of root.querySelectorAll('a[href^="mailto:" i]')) acc.mailtos.push(a.getAttribute('href'));
+  };
+  const walk = (root, acc, depth) => {
+    for (const el of root.querySelectorAll('*')) {
+      const sr = el.shadowRoot;
+      if (sr) {
+        for (const c of sr.children) {
+          if (c.tagName !== 'STYLE' && c.tagName !== 'SCRIPT') acc.text.push(c.innerText || c.textContent || '');
+        }
+        acc.html.push(sr.innerHTML);
+        mailtos(sr, acc);
+        walk(sr, acc, depth);
+      }
+      if ((el.tagName === 'IFRAME' || el.tagName === 'FRAME') && depth < maxDepth) {
+        let sub = null;
+        try { sub = el.contentDocument; } catch (e) {}
+        if (sub && sub.documentElement) doc(sub, acc.url, depth + 1);
+        else if (/^https?:/i.test(el.src || '')) cross.push(el.src);
+      }
+    }
+  };
+  const doc = (d, parentURL, depth) => {
+    const href = d.location ? d.location.href : '';
+    const acc = {url: /^https?:/i.test(href) ? href : parentURL, text: [], html: [], mailtos: []};
+    if (depth > 0 && d.body) {
+      acc.text.push(d.body.innerText || '');
+      acc.html.push(d.body.outerHTML);
+      mailtos(d, acc);
+    }
+    walk(d, acc, depth);
+    if (acc.text.join('').trim() || acc.mailtos.length) {
+      frames.push({url: acc.url, text: acc.text.join('\n'), html: acc.html.join('\n'), mailtos: acc.mailtos});
+    }
+  };
+  doc(document, location.href, 0);
+  return {frames, cross};
+})()`
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// =================== Gruppierte Adressen ===================
+//
+// Paper listen Autoren oft gesammelt:
+//   {first1.last1, first2.last2}@dept.uni.edu
+//   (alice; bob)@lab.org
+//   alice|bob@lab.org
+// Der Expander liefert pro Local-Part einen eigenen Kandidaten mit der
+// gemeinsamen Domain. Bewertet wird jeder Kandidat einzeln.
+
+var (
+	reGroupedBraced = regexp.MustCompile(`(?i)[\{\(\[]\s*([a-z0-9._%+\-]+(?:\s*[,;|/]\s*[a-z0-9._%+\-]+)+)\s*,?\s*[\}\)\]]\s*@\s*([a-z0-9.\-]+\.[a-z]{2,})`)
+	reGroupedPiped  = regexp.MustCompile(`(?i)([a-z0-9._%+\-]+(?:\s*\|\s*[a-z0-9._%+\-]+)+)\s*@\s*([a-z0-9.\-]+\.[a-z]{2,})`)
+	reGroupSep      = regexp.MustCompile(`\s*[,;|/]\s*`)
+)
+
+// expandGroupedEmails findet gruppierte Schreibweisen und expandiert sie.
+// Nur plausible Local-Parts / Domains werden zurückgegeben (dedupliziert).
+func expandGroupedEmails(text string) []string {
+	hits := expandGroupedEmailsAt(text)
+	if len(hits) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(hits))
+	for _, h := range hits {
+		out = append(out, h.Email)
+	}
+	return out
+}
+
+// posEmail ist eine Adresse mit Byte-Offset der Fundstelle im Text.
+type posEmail struct {
+	Email string
+	Pos   int
+}
+
+// expandGroupedEmailsAt wie expandGroupedEmails, aber mit Position der Gruppe;
+// die Reihenfolge innerhalb der Gruppe bleibt erhalten (= Autorenreihenfolge).
+func expandGroupedEmailsAt(text string) []posEmail {
+	if !strings.Contains(text, "@") {
+		return nil
+	}
+	var out []posEmail
+	seen := map[string]struct{}{}
+	expand := func(matches [][]int) {
+		for _, m := range matches {
+			if len(m) < 6 {
+				continue
+			}
+			domain := strings.ToLower(strings.Trim(text[m[4]:m[5]], "."))
+			if !validDomain(domain) {
+				continue
+			}
+			for _, local := range reGroupSep.Split(text[m[2]:m[3]], -1) {
+				local = strings.ToLower(strings.Trim(strings.TrimSpace(local), "."))
+				if local == "" || !reLocalOK.MatchString(local) {
+					continue
+				}
+				em := local + "@" + domain
+				if _, ok := seen[em]; ok {
+					continue
+				}
+				seen[em] = struct{}{}
+				out = append(out, posEmail{Email: em, Pos: m[0]})
+			}
+		}
+	}
+	expand(reGroupedBraced.FindAllStringSubmatchIndex(text, -1))
+	expand(reGroupedPiped.FindAllStringSubmatchIndex(text, -1))
+	return out
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+)
+
+// =================== Browser-Interaktionen ===================
+//
+// Manche Verzeichnisseiten zeigen Adressen erst nach einem Klick
+// („E-Mail anzeigen“), hinter einem Consent-Overlay oder nach dem Scrollen
+// (lazy geladene Karten). Vor der Extraktion laufen deshalb konfigurierbare
+// Schritte (--interact=consent,reveal,scroll,idle; „none“ schaltet ab).
+// Alle Schritte sind best effort: Fehler brechen das Rendern nicht ab.
+
+const (
+	interactConsent = "consent" // Cookie-/Consent-Banner wegklicken
+	interactReveal  = "reveal"  // „Show email“-Buttons klicken
+	interactScroll  = "scroll"  // bis zum Seitenende scrollen (Lazy Loading)
+	interactIdle    = "idle"    // auf Netzwerkruhe warten
+)
+
+var interactionSteps = []string{interactConsent, interactReveal, interactScroll, interactIdle}
+
+const (
+	netIdleQuiet   = 500 * time.Millisecond // so lange keine offene Anfrage
+	netIdleMaxWait = 3 * time.Second
+	clickSettle    = 300 * time.Millisecond
+)
+
+// -------------------- Netzwerkruhe --------------------
+
+// netTracker zählt offene Anfragen eines Tabs (Listener endet mit ctx).
+type netTracker struct {
+	mu       sync.Mutex
+	inflight map[network.RequestID]struct{}
+	last     time.Time // letzte Änderung
+}
+
+func trackNetwork(ctx context.Context) *netTracker {
+	t := &netTracker{inflight: map[network.RequestID]struct{}{}, last: time.Now()}
+	chromedp.ListenTarget(ctx, func(ev any) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		switch e := ev.(type) {
+		case *network.EventRequestWillBeSent:
+			t.inflight[e.RequestID] = struct{}{}
+		case *network.EventLoadingFinished:
+			delete(t.inflight, e.RequestID)
+		case *network.EventLoadingFailed:
+			delete(t.inflight, e.RequestID)
+		default:
+			return
+		}
+		t.last = time.Now()
+	})
+	return t
+}
+
+// waitIdle wartet, bis quiet lang keine Anfrage offen war (höchstens maxWait).
+func (t *netTracker) waitIdle(quiet, maxWait time.Duration) chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		deadline := time.Now().Add(maxWait)
+		for time.Now().Before(deadline) {
+			t.mu.Lock()
+			idle := len(t.inflight) == 0 && time.Since(t.last) >= quiet
+			t.mu.Unlock()
+			if idle {
+				return nil
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(100 * time.Millisecond):
+			}
+		}
+		return nil // lange Verbindungen (Websocket, Polling) nicht abwarten
+	})
+}
+
+// -------------------- Schritte --------------------
+
+// runInteractions führt die konfigurierten Schritte aus und liefert eine Kurzfassung fürs Log.
+func runInteractions(ctx context.Context, steps []string, net *netTracker) string {
+	var done []string
+	for _, step := range steps {
+		switch step {
+		case interactConsent, interactReveal:
+			js := jsDismissConsent
+			if step == interactReveal {
+				js = jsClickReveal
+			}
+			var clicks int
+			if err := chromedp.Run(ctx, chromedp.Evaluate(js, &clicks)); err != nil || clicks == 0 {
+				continue
+			}
+			_ = chromedp.Run(ctx, chromedp.Sleep(clickSettle))
+			done = append(done, fmt.Sprintf("%s=%d", step, clicks))
+		case interactScroll:
+			var height int
+			if err := chromedp.Run(ctx, chromedp.Evaluate(jsScrollToEnd, &height, awaitPromise)); err == nil {
+				done = append(done, step)
+			}
+		case interactIdle:
+			if net != nil && chromedp.Run(ctx, net.waitIdle(netIdleQuiet, netIdleMaxWait)) == nil {
+				done = append(done, step)
+			}
+		}
+	}
+	return strings.Join(done, " ")
+}
+
+func awaitPromise(p *runtime.EvaluateParams) *runtime.EvaluateParams {
+	return p.WithAwaitPromise(true)
+}
+
+// Bekannte Consent-Tools zuerst, sonst Zustimmungs-Buttons innerhalb eines Banners/Dialogs.
+const jsDismissConsent = `(() => {
+  const sels = ['#onetrust-accept-btn-handler', '#CybotCookiebotDialogBodyLevelButtonLevelOptinAllowAll',
+    '#CybotCookiebotDialogBodyButtonAccept', '#didomi-notice-agree-button', '#uc-btn-accept-banner',
+    'button[data-testid="uc-accept-all-button"]', '[data-cookiebanner="accept_button"]',
+    '.cc-allow', '.cc-accept', '.cc-dismiss', '.klaro .cm-btn-success', '#cookie-accept', '.cookie-consent-accept'];
+  let n = 0;
+  for (const s of sels) {
+    for (const el of document.querySelectorAll(s)) {
+      if (el.offsetParent !== null) { el.click(); n++; }
+    }
+  }
+  if (n) return n;
+  const re = /^(alle\s+)?(akzeptieren|zustimmen|annehmen|einverstanden|accept( all)?( cookies)?|allow( all)?( cookies)?|agree|i agree|ok|got it|tout accepter|accepter|aceptar( todo)?|accetta( tutto)?)\b/i;
+  const box = '[id*=cookie i],[class*=cookie i],[id*=consent i],[class*=consent i],[role=dialog],[aria-modal=true]';
+  for (const el of document.querySelectorAll('button, [role=button], a[href="#"], input[type=button], input[type=submit]')) {
+    const t = (el.innerText || el.value || '').trim();
+    if (t.length <= 40 && re.test(t) && el.offsetParent !== null && el.closest(box)) { el.click(); n++; }
+  }
+  return n;
+})()`
+
+// Klickt das innerste Element mit Reveal-Text; echte Links (Navigation) bleiben unberührt.
+const jsClickReveal = `(() => {
+  const re = /((show|reveal|display|view)\s*(the\s*)?e-?mail|e-?mail(\s*-?adresse|\s*address)?\s*(anzeigen|einblenden|zeigen)|adresse\s*anzeigen|click to (show|reveal)|afficher (l'|le |la )?(e-?mail|courriel|adresse)|mostrar (el )?(correo|e-?mail)|mostra (l'|la )?e-?mail)/i;
+  const hits = [];
+  for (const el of document.querySelectorAll('button, [role=button], a, span, div[onclick], [data-email], [data-mail]')) {
+    const t = (el.innerText || el.getAttribute('aria-label') || el.title || '').trim();
+    if (!t || t.length > 60 || !re.test(t)) continue;
+    if (el.tagName === 'A') {
+      const h = (el.getAttribute('href') || '').trim().toLowerCase();
+      if (h && h !== '#' && !h.startsWith('javascript:')) continue;
+    }
+    hits.push(el);
+  }
+  let n = 0;
+  for (const el of hits) {
+    if (n >= 10) break;
+    if (hits.some(o => o !== el && el.contains(o))) continue; // Klick würde doppelt bubbeln
+    el.click(); n++;
+  }
+  return n;
+})()`
+
+// Scrollt schrittweise, bis die Seitenhöhe stabil ist (max. 8 Runden).
+const jsScrollToEnd = `(async () => {
+  let last = 0;
+  for (let i = 0; i < 8; i++) {
+    window.scrollTo(0, document.body.scrollHeight);
+    await new Promise(r => setTimeout(r, 300));
+    const h = document.body.scrollHeight;
+    if (h === last) break;
+    last = h;
+  }
+  window.scrollTo(0, 0);
+  return last;
+})()`
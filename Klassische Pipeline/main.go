@@ -1,17 +1,12 @@
 package main
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"io"
 	"math/rand"
 	"net/url"
 	"os"
-	"os/exec"
 	"regexp"
-	"runtime/debug"
-	"strconv"
 	"strings"
 	"time"
 )
@@ -22,6 +17,7 @@ const (
 	maxLinksPhase1   = 10
 	maxLinksFallback = 10
 	maxLinksPDF      = 6 // weniger PDFs pro Person → stabiler
+	maxLinksDocs     = 3 // zusätzlich DOCX/ODT/… (siehe docextract.go)
 
 	hardAcceptScore   = 14 // sehr sicher -> sofort final
 	consensusMinScore = 6  // Konsens braucht mind. diesen Score
@@ -30,26 +26,48 @@ const (
 // -------------------- Kandidaten-Aggregation -------------------
 
 type ResultRow struct {
-	Name   string
-	Email  string
-	Time   string
-	Source string
+	Name     string
+	Email    string
+	Time     string
+	Source   string
+	Mode     string // Abrufmodus der Quelle (fetchMode* in fetch.go)
+	Pick     string // finale Auswahl: pickConsensus | pickBestOverall ("" = Early-Accept)
+	Via      string // Herkunft im Dokument (pdfVia*, z. B. repaired:hyphen), "" bei Webseiten
+	Evidence string // Beleg-Verzeichnis (Screenshot, DOM), siehe evidence.go
 }
 
 type candInfo struct {
-	bestScore int
-	sources   map[string]struct{} // Set verschiedener Quellen (Host+Path)
+	bestScore  int
+	bestSource string              // Quelle (URL/Pfad) mit dem besten Score
+	bestMode   string              // Abrufmodus der Quelle mit dem besten Score
+	bestVia    string              // Herkunft im Dokument (pdfVia*) beim besten Score
+	sources    map[string]struct{} // Set verschiedener Quellen (Host+Path)
 }
 
+// Art der finalen Auswahl (ResultRow.Pick)
+const (
+	pickConsensus   = "consensus"
+	pickBestOverall = "best-overall"
+)
+
 // --------------------------- main ------------------------------
 
 func main() {
-	// Eingabedatei (optional via CLI-Arg überschreibbar)
-	inputFile := "list_of_names_and_affiliations.csv"
-	if len(os.Args) > 1 && strings.TrimSpace(os.Args[1]) != "" {
-		inputFile = os.Args[1]
+	// PDF-Worker (vom Pool gestartet, siehe pdfworker.go)
+	if len(os.Args) > 1 && os.Args[1] == pdfWorkerFlag {
+		runPDFWorker()
+		return
 	}
 
+	// Flags + Eingabedatei (optional via CLI-Arg überschreibbar)
+	if err := parseRunFlags(os.Args[1:]); err != nil {
+		fmt.Printf("Fehler in den Argumenten: %v\n", err)
+		return
+	}
+	inputFile := runCfg.InputFile
+	defer closeHeadlessPool()
+	defer closePDFWorkers()
+
 	entries, err := ReadCSV(inputFile)
 	if err != nil {
 		fmt.Printf("Fehler beim Lesen der CSV (%s): %v\n", inputFile, err)
@@ -61,6 +79,12 @@ func main() {
 	}
 	fmt.Printf("📄 Eingelesen: %d Einträge aus %s\n", len(entries), inputFile)
 
+	// Offline-Korpus statt Websuche (corpus.go)
+	if runCfg.CorpusDir != "" {
+		runCorpusMode(entries)
+		return
+	}
+
 	results := make([]ResultRow, 0, len(entries))
 	foundCount := 0
 	startAll := time.Now()
@@ -70,7 +94,7 @@ PERSON_LOOP:
 	for i, entry := range entries {
 		// kleine Höflichkeitspause zwischen Personen
 		if i > 0 {
-			time.Sleep(time.Duration(1500+rand.Intn(2000)) * time.Millisecond)
+			politeSleep(time.Duration(1500+rand.Intn(2000)) * time.Millisecond)
 		}
 
 		contactQuery := buildQuery(entry)
@@ -85,6 +109,20 @@ PERSON_LOOP:
 		// Kandidaten sammeln über alle Phasen
 		candidates := map[string]*candInfo{}
 
+		// ----------------- Phase 0: Sitemap der Institution ----------------
+		// Domain bekannt (Hint / frühere Person) → Sitemap noch vor der Suche
+		sitemapDone := false
+		if domain := knownInstitutionDomain(entry); domain != "" && runCfg.UseSitemaps {
+			sitemapDone = true
+			if finalized, email, src, mode := processSitemapEarly(domain, contactQuery, candidates); finalized {
+				row.Email, row.Source, row.Mode = email, src, mode
+				addResultOnce(&results, row)
+				foundCount++
+				fmt.Printf("✅ Found (early): %s => %s\n", contactQuery, row.Email)
+				continue PERSON_LOOP
+			}
+		}
+
 		// ----------------- Phase 1: DDG → Colly → Chromedp -----------------
 		phase1Links, err := DuckDuckGoSearch(contactQuery)
 		if err != nil {
@@ -93,25 +131,29 @@ PERSON_LOOP:
 		}
 		fmt.Printf("🔎 Phase1: %d Links\n", len(phase1Links))
 
-		if len(phase1Links) > maxLinksPhase1 {
-			phase1Links = phase1Links[:maxLinksPhase1]
+		// Domain erst aus den Treffern ableitbar → Sitemap vor den Treffern
+		if !sitemapDone && runCfg.UseSitemaps {
+			if domain := learnInstitutionDomain(entry, phase1Links); domain != "" {
+				if finalized, email, src, mode := processSitemapEarly(domain, contactQuery, candidates); finalized {
+					row.Email, row.Source, row.Mode = email, src, mode
+					addResultOnce(&results, row)
+					foundCount++
+					fmt.Printf("✅ Found (early): %s => %s\n", contactQuery, row.Email)
+					continue PERSON_LOOP
+				}
+			}
 		}
 
-		// Colly mit Early-Accept
-		if finalized, email, src := processLinksCollyEarly(phase1Links, contactQuery, candidates); finalized {
-			row.Email, row.Source = email, src
-			addResultOnce(&results, row)
-			foundCount++
-			fmt.Printf("✅ Found (early): %s => %s\n", contactQuery, row.Email)
-			continue PERSON_LOOP
+		if len(phase1Links) > maxLinksPhase1 {
+			phase1Links = phase1Links[:maxLinksPhase1]
 		}
 
-		// Chromedp mit Early-Accept
-		if finalized, email, src := processLinksChromedpEarly(phase1Links, contactQuery, candidates); finalized {
-			row.Email, row.Source = email, src
+		// statisch (Colly), nur JS-Seiten zusätzlich per Chromedp – mit Early-Accept
+		if finalized, email, src, mode := processLinksAdaptiveEarly(phase1Links, contactQuery, candidates); finalized {
+			row.Email, row.Source, row.Mode = email, src, mode
 			addResultOnce(&results, row)
 			foundCount++
-			fmt.Printf("✅ Found (early): %s => %s\n", contactQuery, row.Email)
+			fmt.Printf("✅ Found (early, %s): %s => %s\n", mode, contactQuery, row.Email)
 			continue PERSON_LOOP
 		}
 
@@ -127,92 +169,143 @@ PERSON_LOOP:
 			fallbackLinks = fallbackLinks[:maxLinksFallback]
 		}
 
-		// Colly mit Early-Accept
-		if finalized, email, src := processLinksCollyEarly(fallbackLinks, contactQuery, candidates); finalized {
-			row.Email, row.Source = email, src
+		// statisch (Colly), nur JS-Seiten zusätzlich per Chromedp – mit Early-Accept
+		if finalized, email, src, mode := processLinksAdaptiveEarly(fallbackLinks, contactQuery, candidates); finalized {
+			row.Email, row.Source, row.Mode = email, src, mode
 			addResultOnce(&results, row)
 			foundCount++
-			fmt.Printf("✅ Found (early): %s => %s\n", contactQuery, row.Email)
+			fmt.Printf("✅ Found (early, %s): %s => %s\n", mode, contactQuery, row.Email)
 			continue PERSON_LOOP
 		}
 
-		// Chromedp mit Early-Accept
-		if finalized, email, src := processLinksChromedpEarly(fallbackLinks, contactQuery, candidates); finalized {
-			row.Email, row.Source = email, src
-			addResultOnce(&results, row)
-			foundCount++
-			fmt.Printf("✅ Found (early): %s => %s\n", contactQuery, row.Email)
-			continue PERSON_LOOP
+		// ----------------- Phase 2: PDFs und weitere Dokumente --------------
+		// zuerst gespeicherte Dokumente, in denen die Person als Autor steht (docstore.go)
+		storeDocs := map[string]bool{}
+		if runCfg.DocStore {
+			for _, rec := range docStoreForPerson(entry.Name) {
+				storeDocs[rec.SHA256] = true
+				res := rec.rescore(contactQuery)
+				logDocReuse(rec, contactQuery, "Autor", res)
+				if email, via := registerDocCandidates(candidates, res.Candidates, rec.URLs[0], docRecordMode(rec.Type)); email != "" {
+					row.Email, row.Source, row.Mode, row.Via = email, rec.URLs[0], docRecordMode(rec.Type), via
+					addResultOnce(&results, row)
+					foundCount++
+					fmt.Printf("✅ Found (early, Dokument-Store): %s => %s\n", contactQuery, row.Email)
+					continue PERSON_LOOP
+				}
+			}
 		}
 
-		// ----------------- Phase 2: PDFs -----------------------------------
-		pdfQuery := contactQuery + " filetype:pdf"
-		pdfLinks, perr := DuckDuckGoPDFSearch(pdfQuery)
-
-		// ----- Worker-Mode für sichere PDF-Analyse (Subprozess) -----
-		if len(os.Args) > 1 && os.Args[1] == "--scanpdf" {
-			// Erwartet: --scanpdf <pdfPath> <person>
-			if len(os.Args) < 4 {
-				fmt.Println("NONE")
-				return
+		var pdfLinks []string
+		if docTypeEnabled(docTypePDF) {
+			pdfQuery := contactQuery + " filetype:pdf"
+			links, perr := DuckDuckGoPDFSearch(pdfQuery)
+			if perr != nil {
+				fmt.Printf("⚠️ DuckDuckGo (PDF) Fehler: %v\n", perr)
+				links = nil
 			}
-			// Hartes Heap-Limit nur für den Worker (z. B. 200 MiB)
-			debug.SetMemoryLimit(200 << 20)
-			pdfPath := os.Args[2]
-			person := os.Args[3]
-
-			ctx, cancel := context.WithTimeout(context.Background(), 12*time.Second)
-			defer cancel()
-
-			email, score, err := ExtractEmailsFromPDFCtx(ctx, pdfPath, person)
-			if err != nil || email == "" {
-				fmt.Println("NONE")
-				return
+			fmt.Printf("🔎 PDF: %d Links\n", len(links))
+			if len(links) > maxLinksPDF {
+				links = links[:maxLinksPDF]
 			}
-			fmt.Printf("OK|%s|%d\n", email, score)
-			return
+			pdfLinks = links
 		}
-
-		if perr != nil {
-			fmt.Printf("⚠️ DuckDuckGo (PDF) Fehler: %v\n", perr)
-			pdfLinks = nil
+		if docQuery := docSearchQuery(contactQuery); docQuery != "" {
+			docLinks, derr := DuckDuckGoPDFSearch(docQuery)
+			if derr != nil {
+				fmt.Printf("⚠️ DuckDuckGo (Dokumente) Fehler: %v\n", derr)
+			}
+			fmt.Printf("🔎 Dokumente: %d Links\n", len(docLinks))
+			if len(docLinks) > maxLinksDocs {
+				docLinks = docLinks[:maxLinksDocs]
+			}
+			pdfLinks = append(pdfLinks, docLinks...)
 		}
-		fmt.Printf("🔎 PDF: %d Links\n", len(pdfLinks))
-		if len(pdfLinks) > maxLinksPDF {
-			pdfLinks = pdfLinks[:maxLinksPDF]
+		// beide Suchen liefern oft dieselben PDFs → jede URL nur einmal laden
+		seenLinks := make(map[string]bool, len(pdfLinks))
+		uniqueLinks := pdfLinks[:0]
+		for _, l := range pdfLinks {
+			if !seenLinks[l] {
+				seenLinks[l] = true
+				uniqueLinks = append(uniqueLinks, l)
+			}
 		}
+		pdfLinks = uniqueLinks
 
 		for _, pdfURL := range pdfLinks {
-			// leichte Pause zwischen PDFs, um Blockaden zu vermeiden
-			time.Sleep(time.Duration(3000+rand.Intn(1500)) * time.Millisecond)
-			tmp, terr := os.CreateTemp("", "emailpdf_*.pdf")
-			if terr != nil {
-				continue
+			// schon gescannt (gleiche URL) → neu bewerten statt herunterladen
+			var (
+				reused  *docRecord
+				res     pdfScanResult
+				docType string
+			)
+			if runCfg.DocStore {
+				if rec := docStoreByURL(pdfURL); rec != nil && !storeDocs[rec.SHA256] {
+					if r := rec.rescore(contactQuery); rec.usable(r) {
+						reused, res, docType = rec, r, rec.Type
+						logDocReuse(rec, contactQuery, "URL", res)
+					}
+				} else if rec != nil {
+					continue // schon aus der Autorensuche bewertet
+				}
 			}
-			tmp.Close()
-			defer os.Remove(tmp.Name())
-			if derr := DownloadPDF(pdfURL, tmp.Name()); derr != nil {
-				continue
-			}
-			start := time.Now()
-			// Subprozess mit hartem Timeout pro PDF
-			ctxPDF, cancelPDF := context.WithTimeout(context.Background(), 12*time.Second)
-			email, score, werr := scanPDFInSubprocess(ctxPDF, tmp.Name(), contactQuery)
-			cancelPDF()
-			fmt.Printf("⏱️ [PDF fast] %s: %.2fs\n", contactQuery, time.Since(start).Seconds())
-			if werr != nil {
-				// Worker-Timeout/Crash → einfach nächste PDF
-				fmt.Printf("⏭️ Skip PDF (worker err: %v)\n", werr)
-				continue
+
+			if reused == nil {
+				// leichte Pause zwischen PDFs, um Blockaden zu vermeiden
+				politeSleep(time.Duration(3000+rand.Intn(1500)) * time.Millisecond)
+				tmp, terr := os.CreateTemp("", "emaildoc_*")
+				if terr != nil {
+					continue
+				}
+				tmp.Close()
+				defer os.Remove(tmp.Name())
+				contentType, derr := DownloadDocument(pdfURL, tmp.Name())
+				if derr != nil {
+					continue
+				}
+
+				// gleicher Inhalt unter anderer URL → kein neuer Scan
+				sha := ""
+				if runCfg.DocStore {
+					sha, _ = fileSHA256(tmp.Name())
+					if rec := docStoreBySHA(sha); rec != nil && sha != "" {
+						if storeDocs[sha] {
+							docStorePut(sha, pdfURL, rec.Type, docHarvest{})
+							continue
+						}
+						if r := rec.rescore(contactQuery); rec.usable(r) {
+							reused, res, docType = docStorePut(sha, pdfURL, rec.Type, docHarvest{}), r, rec.Type
+							logDocReuse(rec, contactQuery, "Inhalt", res)
+						}
+					}
+				}
+
+				if reused == nil {
+					start := time.Now()
+					// Worker-Pool mit hartem Timeout pro Dokument
+					ctxPDF, cancelPDF := context.WithTimeout(context.Background(), pdfJobTimeout)
+					var werr error
+					docType, res, werr = pdfWorkers().scan(ctxPDF, tmp.Name(), contentType, contactQuery)
+					cancelPDF()
+					fmt.Printf("⏱️ [%s fast] %s: %.2fs (%d Kandidaten)\n", strings.ToUpper(docType), contactQuery, time.Since(start).Seconds(), len(res.Candidates))
+					if werr != nil && len(res.Candidates) == 0 {
+						// Worker-Timeout/Crash → einfach nächste PDF
+						fmt.Printf("⏭️ Skip PDF (worker err: %v)\n", werr)
+						continue
+					}
+					if sha != "" {
+						docStorePut(sha, pdfURL, docType, res.Harvest)
+					}
+				}
 			}
-			if email == "" {
-				continue
+			if reused != nil {
+				storeDocs[reused.SHA256] = true
 			}
 
-			registerCandidate(candidates, email, score, pdfURL)
-			// Early-Accept in PDF-Phase
-			if shouldEarlyAccept(candidates, email, score) {
-				row.Email, row.Source = email, pdfURL
+			// alle Kandidaten zählen (Konsens über mehrere PDFs), bester zuerst; Early-Accept
+			mode := docRecordMode(docType)
+			if email, via := registerDocCandidates(candidates, res.Candidates, pdfURL, mode); email != "" {
+				row.Email, row.Source, row.Mode, row.Via = email, pdfURL, mode, via
 				addResultOnce(&results, row)
 				foundCount++
 				fmt.Printf("✅ Found (early): %s => %s\n", contactQuery, row.Email)
@@ -221,67 +314,33 @@ PERSON_LOOP:
 		}
 
 		// ----------------- Finale Auswahl (wenn kein Early-Accept) ----------
-		finalEmail, finalSource := pickFinal(candidates, consensusMinScore)
-		row.Email = finalEmail
-		row.Source = finalSource
+		row.Email, row.Pick = pickFinal(candidates, consensusMinScore)
+		if info := candidates[row.Email]; info != nil {
+			row.Source, row.Mode, row.Via = info.bestSource, info.bestMode, info.bestVia
+		}
 
 		addResultOnce(&results, row)
 
 		if row.Email != "" {
 			foundCount++
-			fmt.Printf("✅ Found: %s => %s\n", contactQuery, row.Email)
+			fmt.Printf("✅ Found (%s, %s): %s => %s (%s)\n", row.Mode, row.Pick, contactQuery, row.Email, row.Source)
 		} else {
 			fmt.Printf("❌ Keine passende E-Mail gefunden für: %s\n", contactQuery)
 		}
 	}
 
-	// Ausgabe schreiben
+	writeResults(results, foundCount, len(entries), startAll)
+}
+
+// writeResults schreibt die Ergebnis-CSV und die Zusammenfassung.
+func writeResults(results []ResultRow, foundCount, total int, startAll time.Time) {
 	output := fmt.Sprintf("results_%d.csv", time.Now().Unix())
 	if err := WriteCSV(output, results); err != nil {
 		fmt.Printf("Fehler beim Schreiben der Ergebnisse: %v\n", err)
 	} else {
 		fmt.Printf("\n💾 Ergebnisse gespeichert in: %s  (Treffer: %d/%d)  ⏱️ Gesamt: %.2fs\n",
-			output, foundCount, len(entries), time.Since(startAll).Seconds())
-	}
-}
-
-// --------------------- Subprozess-Wrapper -----------------------
-
-func scanPDFInSubprocess(ctx context.Context, path, person string) (string, int, error) {
-	exe, err := os.Executable()
-	if err != nil {
-		return "", 0, err
-	}
-	cmd := exec.CommandContext(ctx, exe, "--scanpdf", path, person)
-
-	// Hartes Heap-Limit im Child via Env (Go 1.19+)
-	cmd.Env = append(os.Environ(), "GOMEMLIMIT=200MiB")
-
-	var out bytes.Buffer
-	cmd.Stdout = &out
-	cmd.Stderr = io.Discard // PDF-Lib Debug-Noise ignorieren
-
-	if err := cmd.Run(); err != nil {
-		// Timeout / Crash → als Fehler zurück; Aufrufer macht einfach weiter
-		return "", 0, err
+			output, foundCount, total, time.Since(startAll).Seconds())
 	}
-
-	// Robustes Parsing (letzte OK| Zeile suchen; Debug-Zeilen ignorieren)
-	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
-	for i := len(lines) - 1; i >= 0; i-- {
-		line := strings.TrimSpace(lines[i])
-		if line == "NONE" || line == "" {
-			return "", 0, nil
-		}
-		if strings.HasPrefix(line, "OK|") {
-			parts := strings.Split(line, "|")
-			if len(parts) >= 3 {
-				sc, _ := strconv.Atoi(parts[2])
-				return parts[1], sc, nil
-			}
-		}
-	}
-	return "", 0, nil
 }
 
 // --------------------------- Query-Helfer -----------------------
@@ -347,25 +406,50 @@ func shouldEarlyAccept(cands map[string]*candInfo, email string, score int) bool
 	return false
 }
 
-func registerCandidate(cands map[string]*candInfo, email string, score int, src string) {
+// registerDocCandidates zählt alle Kandidaten eines Dokuments und liefert die
+// Adresse, die das Early-Accept erfüllt ("" = keine), samt ihrer Herkunft.
+func registerDocCandidates(cands map[string]*candInfo, docCands []pdfCandidate, src, mode string) (email, via string) {
+	for _, c := range docCands {
+		if c.Score <= 0 {
+			continue
+		}
+		if strings.HasPrefix(c.Via, "repaired:") {
+			fmt.Printf("🩹 [PDF] %s (%s)\n", c.Email, c.Via)
+		}
+		registerCandidate(cands, c.Email, c.Score, src, mode, c.Via)
+	}
+	for _, c := range docCands {
+		if c.Score > 0 && shouldEarlyAccept(cands, c.Email, c.Score) {
+			return c.Email, c.Via
+		}
+	}
+	return "", ""
+}
+
+// registerCandidate zählt eine Adresse; via = Herkunft im Dokument ("" bei Webseiten).
+func registerCandidate(cands map[string]*candInfo, email string, score int, src, mode, via string) {
 	if email == "" {
 		return
 	}
 	info, ok := cands[email]
 	if !ok {
-		info = &candInfo{bestScore: score, sources: map[string]struct{}{}}
+		info = &candInfo{bestScore: score, bestSource: src, bestMode: mode, bestVia: via, sources: map[string]struct{}{}}
 		cands[email] = info
 	} else if score > info.bestScore {
 		info.bestScore = score
+		info.bestSource = src
+		info.bestMode = mode
+		info.bestVia = via
 	}
 	info.sources[sourceKey(src)] = struct{}{}
 }
 
-func pickFinal(cands map[string]*candInfo, minScore int) (email, source string) {
+// pickFinal wählt die Adresse ohne Early-Accept; die Quelle steht in candInfo.bestSource.
+func pickFinal(cands map[string]*candInfo, minScore int) (email, pick string) {
 	// 1) Konsens bevorzugen
 	for em, info := range cands {
 		if info.bestScore >= minScore {
-			return em, "(consensus)"
+			return em, pickConsensus
 		}
 	}
 	// 2) sonst besten Kandidaten nehmen
@@ -377,7 +461,7 @@ func pickFinal(cands map[string]*candInfo, minScore int) (email, source string)
 		}
 	}
 	if bestEmail != "" {
-		return bestEmail, "(best-overall)"
+		return bestEmail, pickBestOverall
 	}
 	return "", ""
 }
@@ -400,37 +484,62 @@ func addResultOnce(results *[]ResultRow, row ResultRow) {
 		return
 	}
 	seenResults[key] = struct{}{}
+	if row.Email != "" {
+		row.Evidence = captureEvidence(row)
+	}
 	*results = append(*results, row)
 }
 
-func processLinksCollyEarly(links []string, contactQuery string, candidates map[string]*candInfo) (finalized bool, email, source string) {
+// processSitemapEarly sucht Profil-URLs der Person in den Sitemaps der Domain
+// und wertet sie wie DDG-Treffer aus.
+func processSitemapEarly(domain, contactQuery string, candidates map[string]*candInfo) (finalized bool, email, source, mode string) {
+	first, _, last, _ := splitNameAndOrgNoLists(contactQuery)
+	links := discoverSitemapProfiles(domain, first, last)
+	fmt.Printf("🗺️ Sitemap-Profile (%s): %d Links\n", domain, len(links))
+	if len(links) == 0 {
+		return false, "", "", ""
+	}
+	return processLinksAdaptiveEarly(links, contactQuery, candidates)
+}
+
+// processLinksAdaptiveEarly holt jede Seite einmal statisch (Colly) und eskaliert nur Seiten,
+// die JavaScript brauchen (siehe needsJavaScript), an den Headless-Browser.
+func processLinksAdaptiveEarly(links []string, contactQuery string, candidates map[string]*candInfo) (finalized bool, email, source, mode string) {
+	var escalate []string
 	for _, link := range links {
-		start := time.Now()
-		em, score, err := ExtractEmailWithColly(link, contactQuery)
-		fmt.Printf("⏱️ [Colly] %s: %.2fs\n", contactQuery, time.Since(start).Seconds())
-		if err != nil || em == "" {
+		res, err := extractEmailStatic(link, contactQuery)
+		if res.NeedsJS {
+			fmt.Printf("🧭 [Fetch] %s → %s (%s)\n", link, fetchModeHeadless, res.JSReason)
+			escalate = append(escalate, link)
+		} else {
+			fmt.Printf("🧭 [Fetch] %s → %s\n", link, fetchModeStatic)
+		}
+		if err != nil || res.Email == "" {
 			continue
 		}
-		registerCandidate(candidates, em, score, link)
-		if shouldEarlyAccept(candidates, em, score) {
-			return true, em, link
+		// Quelle = Seite, die den Kandidaten geliefert hat (ggf. per Crawl erreicht)
+		registerCandidate(candidates, res.Email, res.Score, res.Page, fetchModeStatic, "")
+		if shouldEarlyAccept(candidates, res.Email, res.Score) {
+			return true, res.Email, res.Page, fetchModeStatic
 		}
 	}
-	return false, "", ""
-}
 
-func processLinksChromedpEarly(links []string, contactQuery string, candidates map[string]*candInfo) (finalized bool, email, source string) {
-	for _, link := range links {
+	for _, link := range escalate {
 		start := time.Now()
-		em, score, err := ExtractEmailFromURL(link, contactQuery)
+		res, err := extractEmailRendered(link, contactQuery)
 		fmt.Printf("⏱️ [Chromedp] %s: %.2fs\n", contactQuery, time.Since(start).Seconds())
-		if err != nil || em == "" {
+		if err != nil || res.Email == "" {
 			continue
 		}
-		registerCandidate(candidates, em, score, link)
-		if shouldEarlyAccept(candidates, em, score) {
-			return true, em, link
+		// aus iframe oder XHR/fetch → dessen URL als Quelle
+		src := link
+		if res.Via != "" {
+			src = res.Via
+		}
+		registerCandidate(candidates, res.Email, res.Score, src, fetchModeHeadless, "")
+		if shouldEarlyAccept(candidates, res.Email, res.Score) {
+			return true, res.Email, src, fetchModeHeadless
 		}
 	}
-	return false, "", ""
+	return false, "", "", ""
 }
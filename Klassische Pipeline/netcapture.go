@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// =================== API-Antworten (XHR/fetch) ===================
+//
+// Moderne Hochschul-CMS laden Profildaten per XHR als JSON nach; die Adresse
+// taucht dann oft nie im sichtbaren Text auf. Der Chromedp-Tab hört deshalb
+// auf Netzwerk-Events und sammelt JSON-/Text-Antworten von Anfragen derselben
+// Site (mit Größenlimit). Kandidaten daraus tragen die API-URL als Quelle.
+
+const (
+	maxAPIResponseBytes = 512 << 10 // je Antwort
+	maxAPITotalBytes    = 4 << 20   // je Seite
+	maxAPIResponses     = 20
+)
+
+// apiResponse ist eine mitgeschnittene Antwort (Teil des gecachten Snapshots).
+type apiResponse struct {
+	URL  string `json:"url"`
+	Body string `json:"body"`
+}
+
+type apiRequest struct {
+	id  network.RequestID
+	url string
+}
+
+// apiCapture merkt sich passende Anfragen; die Bodies werden erst nach dem Laden abgeholt.
+type apiCapture struct {
+	mu      sync.Mutex
+	pending map[network.RequestID]string // Antwort-Header da, Body noch nicht fertig
+	ready   []apiRequest
+}
+
+// captureAPIResponses registriert den Listener (endet mit ctx) – vor Navigate aufrufen.
+func captureAPIResponses(ctx context.Context, pageURL string) *apiCapture {
+	c := &apiCapture{pending: map[network.RequestID]string{}}
+	chromedp.ListenTarget(ctx, func(ev any) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		switch e := ev.(type) {
+		case *network.EventResponseReceived:
+			if e.Type != network.ResourceTypeXHR && e.Type != network.ResourceTypeFetch {
+				return
+			}
+			r := e.Response
+			if r == nil || r.Status != 200 || !apiMimeOK(r.MimeType) || !sameSite(r.URL, pageURL) {
+				return
+			}
+			c.pending[e.RequestID] = r.URL
+		case *network.EventLoadingFinished:
+			u, ok := c.pending[e.RequestID]
+			if !ok {
+				return
+			}
+			delete(c.pending, e.RequestID)
+			if e.EncodedDataLength <= maxAPIResponseBytes && len(c.ready) < maxAPIResponses {
+				c.ready = append(c.ready, apiRequest{id: e.RequestID, url: u})
+			}
+		case *network.EventLoadingFailed:
+			delete(c.pending, e.RequestID)
+		}
+	})
+	return c
+}
+
+// collect holt die Bodies der fertigen Antworten nach out (als Action für chromedp.Run).
+func (c *apiCapture) collect(out *[]apiResponse) chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		c.mu.Lock()
+		reqs := append([]apiRequest(nil), c.ready...)
+		c.mu.Unlock()
+		total := 0
+		for _, r := range reqs {
+			body, err := network.GetResponseBody(r.id).Do(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				continue // Body bereits verworfen (z. B. nach Navigation)
+			}
+			if len(body) > maxAPIResponseBytes || total+len(body) > maxAPITotalBytes {
+				continue
+			}
+			total += len(body)
+			*out = append(*out, apiResponse{URL: r.url, Body: string(body)})
+		}
+		return nil
+	})
+}
+
+// apiMimeOK: JSON und Text (HTML-Fragmente), keine Skripte/Styles.
+func apiMimeOK(mime string) bool {
+	mime = strings.ToLower(mime)
+	if strings.Contains(mime, "json") {
+		return true
+	}
+	return strings.HasPrefix(mime, "text/") && !strings.Contains(mime, "css") && !strings.Contains(mime, "javascript")
+}
+
+// apiResponseText: JSON → alle String-Werte zeilenweise (löst @ & Co. auf), sonst Rohtext.
+func apiResponseText(body string) string {
+	var v any
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		return body
+	}
+	var sb strings.Builder
+	var walk func(x any)
+	walk = func(x any) {
+		switch t := x.(type) {
+		case string:
+			sb.WriteString(t)
+			sb.WriteByte('\n')
+		case []any:
+			for _, e := range t {
+				walk(e)
+			}
+		case map[string]any:
+			for _, e := range t {
+				walk(e)
+			}
+		}
+	}
+	walk(v)
+	return sb.String()
+}
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// =================== Autorenblock (PDF) ===================
+//
+// In Papers mit mehreren Autoren verbinden Reihenfolge und Fußnoten-Marker
+// (¹, *, †) jeden Autor mit seiner Adresse. Der Block wird aus dem Kopf der
+// ersten Seiten gelesen; eine Adresse bekommt nur dann einen Bonus, wenn sie
+// unserer Zielperson zugeordnet ist.
+
+const (
+	authorHeaderMaxBytes = 3000 // Kopfbereich, falls kein „Abstract“ gefunden wird
+	authorMatchBonus     = 5    // Bonus für Adresse der Zielperson
+	authorMarkerLookback = 40   // Zeichen vor einer Adresse, in denen ein Marker gesucht wird
+)
+
+const authorMarkerChars = "¹²³⁴⁵⁶⁷⁸⁹⁰*∗†‡§¶#"
+
+var (
+	reAuthorHeaderEnd  = regexp.MustCompile(`(?i)\b(abstract|introduction|zusammenfassung|keywords)\b`)
+	reAuthorAndSep     = regexp.MustCompile(`(?i)\s+(?:and|und|&)\s+`)
+	reAuthorSplit      = regexp.MustCompile(`[,;\n]+`)
+	reAuthorTrailMarks = regexp.MustCompile(`^(.*?)\s*([` + authorMarkerChars + `0-9]+)$`)
+	reAuthorLeadMarks  = regexp.MustCompile(`^([` + authorMarkerChars + `0-9]+)\s*(.*)$`)
+	reAuthorOnlyMarks  = regexp.MustCompile(`^[` + authorMarkerChars + `0-9\s]+$`)
+	reAuthorMailLabel  = regexp.MustCompile(`(?i)(corresponding\s+authors?|e-?mails?|contact)[\s:.()]*$`)
+)
+
+// Wörter, die einen Abschnitt als Institution statt Person ausweisen.
+var authorOrgWords = []string{
+	"university", "universität", "universitat", "institute", "institut", "department", "dept",
+	"laboratory", "lab", "school", "college", "center", "centre", "faculty", "fakultät",
+	"research", "technology", "sciences", "engineering", "inc", "gmbh", "corporation", "hospital",
+}
+
+type pdfAuthor struct {
+	Name    string
+	First   string // lower, ascii-gefaltet
+	Last    string // lower, ascii-gefaltet
+	Markers string // z. B. "1*"
+}
+
+type pdfAuthorBlock struct {
+	Authors []pdfAuthor
+	Emails  []string
+	Assign  map[string]int // E-Mail → Index in Authors
+}
+
+// parsePDFAuthorBlock liest Autoren, Marker und Adressen aus dem Kopf einer
+// Seite und ordnet Adressen Autoren zu. nil, wenn kein Block erkennbar ist.
+func parsePDFAuthorBlock(raw string) *pdfAuthorBlock {
+	header := raw
+	if loc := reAuthorHeaderEnd.FindStringIndex(header); loc != nil && loc[0] > 0 {
+		header = header[:loc[0]]
+	}
+	if len(header) > authorHeaderMaxBytes {
+		header = header[:authorHeaderMaxBytes]
+	}
+	header = strings.ReplaceAll(header, "\u00a0", " ")
+
+	emails, emailMarks := authorBlockEmails(header)
+	if len(emails) == 0 {
+		return nil
+	}
+	authors := authorBlockNames(header)
+	if len(authors) == 0 {
+		return nil
+	}
+
+	b := &pdfAuthorBlock{Authors: authors, Emails: emails, Assign: map[string]int{}}
+	b.assign(emailMarks)
+	return b
+}
+
+// authorBlockEmails liefert die Adressen im Kopf in Textreihenfolge
+// (inkl. expandierter Gruppen) und den Marker direkt davor.
+func authorBlockEmails(header string) ([]string, map[string]string) {
+	var hits []posEmail
+	seen := map[string]struct{}{}
+	for _, g := range expandGroupedEmailsAt(header) {
+		seen[g.Email] = struct{}{}
+		hits = append(hits, g)
+	}
+	for _, loc := range reEmailNormal.FindAllStringIndex(header, -1) {
+		em := strings.ToLower(sanitizeEmailTight(header[loc[0]:loc[1]]))
+		if em == "" {
+			continue
+		}
+		if _, ok := seen[em]; ok {
+			continue
+		}
+		seen[em] = struct{}{}
+		hits = append(hits, posEmail{Email: em, Pos: loc[0]})
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].Pos < hits[j].Pos })
+
+	emails := make([]string, 0, len(hits))
+	marks := map[string]string{}
+	for _, h := range hits {
+		emails = append(emails, h.Email)
+		from := maxInt(0, h.Pos-authorMarkerLookback)
+		window := header[from:h.Pos]
+		// nur bis zur vorherigen Adresse zurückschauen
+		if at := strings.LastIndexByte(window, '@'); at >= 0 {
+			window = window[at+1:]
+		}
+		if m := trailingAuthorMarker(window); m != "" {
+			marks[h.Email] = m
+		}
+	}
+	return emails, marks
+}
+
+// trailingAuthorMarker liefert das Symbol direkt vor einer Adresse
+// („¹alice@…“, „* Corresponding author: bob@…“). ASCII-Ziffern zählen nicht,
+// die wären von Ziffern im Local-Part nicht zu unterscheiden.
+func trailingAuthorMarker(window string) string {
+	window = strings.TrimRight(window, " \t\r\n:{([")
+	window = strings.TrimRight(reAuthorMailLabel.ReplaceAllString(window, ""), " \t\r\n:")
+	runes := []rune(window)
+	if len(runes) == 0 {
+		return ""
+	}
+	if last := runes[len(runes)-1]; strings.ContainsRune(authorMarkerChars, last) {
+		return string(last)
+	}
+	return ""
+}
+
+// authorBlockNames zerlegt den Kopf in Abschnitte und behält die, die wie
+// Personennamen aussehen (2–4 großgeschriebene Wörter, keine Institution).
+func authorBlockNames(header string) []pdfAuthor {
+	// Erste Zeile mit nur einem „Namen“ ist meist der Titel („Scheduling Mixed-Criticality Systems“).
+	if nl := strings.IndexByte(header, '\n'); nl > 0 && len(authorBlockSegments(header[:nl])) == 1 {
+		header = header[nl+1:]
+	}
+	return authorBlockSegments(header)
+}
+
+func authorBlockSegments(header string) []pdfAuthor {
+	header = reAuthorAndSep.ReplaceAllString(header, ",")
+	var authors []pdfAuthor
+	for _, seg := range reAuthorSplit.Split(header, -1) {
+		seg = strings.TrimSpace(seg)
+		if seg == "" {
+			continue
+		}
+		// reine Marker („2“, „*“) gehören zum vorherigen Autor („Alice Smith1,2“)
+		if reAuthorOnlyMarks.MatchString(seg) {
+			if n := len(authors); n > 0 {
+				authors[n-1].Markers += strings.Join(strings.Fields(seg), "")
+			}
+			continue
+		}
+		markers := ""
+		if m := reAuthorLeadMarks.FindStringSubmatch(seg); m != nil {
+			markers += m[1]
+			seg = m[2]
+		}
+		if m := reAuthorTrailMarks.FindStringSubmatch(seg); m != nil && looksLikePersonName(m[1]) {
+			markers += m[2]
+			seg = m[1]
+		}
+		if !looksLikePersonName(seg) {
+			continue
+		}
+		words := strings.Fields(seg)
+		authors = append(authors, pdfAuthor{
+			Name:    seg,
+			First:   asciiFold(strings.ToLower(strings.Trim(words[0], "."))),
+			Last:    asciiFold(strings.ToLower(words[len(words)-1])),
+			Markers: markers,
+		})
+	}
+	return authors
+}
+
+func looksLikePersonName(s string) bool {
+	words := strings.Fields(s)
+	if len(words) < 2 || len(words) > 4 || strings.Contains(s, "@") {
+		return false
+	}
+	for _, w := range words {
+		r := []rune(w)
+		if !unicode.IsUpper(r[0]) {
+			return false
+		}
+		for _, c := range r {
+			if !unicode.IsLetter(c) && c != '.' && c != '-' && c != '\'' {
+				return false
+			}
+		}
+		lw := strings.ToLower(w)
+		for _, ow := range authorOrgWords {
+			if lw == ow {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// assign: 1) eindeutiger Symbol-Marker, 2) Reihenfolge bei gleicher Anzahl,
+// 3) sonst eindeutig beste Namensähnlichkeit.
+func (b *pdfAuthorBlock) assign(emailMarks map[string]string) {
+	taken := map[int]bool{}
+
+	for _, em := range b.Emails {
+		m := emailMarks[em]
+		if m == "" {
+			continue
+		}
+		hit := -1
+		for i, a := range b.Authors {
+			if strings.Contains(a.Markers, m) {
+				if hit >= 0 {
+					hit = -2 // mehrdeutig
+					break
+				}
+				hit = i
+			}
+		}
+		if hit >= 0 && !taken[hit] {
+			b.Assign[em] = hit
+			taken[hit] = true
+		}
+	}
+
+	var freeEmails []string
+	for _, em := range b.Emails {
+		if _, ok := b.Assign[em]; !ok {
+			freeEmails = append(freeEmails, em)
+		}
+	}
+	var freeAuthors []int
+	for i := range b.Authors {
+		if !taken[i] {
+			freeAuthors = append(freeAuthors, i)
+		}
+	}
+	if len(freeEmails) == 0 || len(freeAuthors) == 0 {
+		return
+	}
+
+	if len(freeEmails) == len(freeAuthors) {
+		for k, em := range freeEmails {
+			b.Assign[em] = freeAuthors[k]
+		}
+		return
+	}
+
+	for _, em := range freeEmails {
+		local, _ := splitEmail(em)
+		best, bestAff, tie := -1, 0, false
+		for _, i := range freeAuthors {
+			if taken[i] {
+				continue
+			}
+			aff := authorEmailAffinity(local, b.Authors[i])
+			switch {
+			case aff > bestAff:
+				best, bestAff, tie = i, aff, false
+			case aff == bestAff && aff > 0:
+				tie = true
+			}
+		}
+		if best >= 0 && !tie && bestAff >= 4 {
+			b.Assign[em] = best
+			taken[best] = true
+		}
+	}
+}
+
+// authorEmailAffinity: günstige Namensähnlichkeit (ohne MX-Lookup).
+func authorEmailAffinity(local string, a pdfAuthor) int {
+	local = asciiFold(strings.ToLower(local))
+	plain := removeSeparators(local)
+	aff := maxInt(prefixRunScore(local, a.First), prefixRunScore(local, a.Last))
+	if len(a.Last) >= 4 && strings.Contains(plain, a.Last[:minInt(6, len(a.Last))]) {
+		aff += 5
+	}
+	aff += twoTokenOrderBonus(splitLocalTokens(local), a.First, a.Last)
+	return aff
+}
+
+// targetIndex sucht die Zielperson im Block (Nachname gleich, Vorname oder Initiale gleich).
+func (b *pdfAuthorBlock) targetIndex(first, last string) int {
+	if b == nil || last == "" {
+		return -1
+	}
+	first = asciiFold(strings.ToLower(first))
+	last = asciiFold(strings.ToLower(last))
+	for i, a := range b.Authors {
+		if a.Last != last {
+			continue
+		}
+		if first == "" || a.First == first || (a.First != "" && first[0] == a.First[0]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// adjustScore: Bonus nur für die Adresse der Zielperson; Adressen, die
+// eindeutig Co-Autoren gehören, dürfen kein Early-Exit mehr auslösen.
+func (b *pdfAuthorBlock) adjustScore(email string, score, target int) int {
+	if b == nil || target < 0 {
+		return score
+	}
+	idx, ok := b.Assign[strings.ToLower(email)]
+	if !ok {
+		return score
+	}
+	if idx == target {
+		return minInt(20, score+authorMatchBonus)
+	}
+	return minInt(score, highConfidenceCutoff-1)
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// =================== Layout-bewusster PDF-Text ===================
+//
+// GetPlainText hängt Glyphen in Stream-Reihenfolge aneinander: Zweispalten-
+// Layouts werden vermischt und Wortgrenzen gehen verloren („USAbaruah@…“,
+// „…recorded@eachstep…“). Hier wird der Seitentext aus den Glyph-Positionen
+// (Page.Content) neu aufgebaut: Zeilen über die Y-Lage, Spalten über einen
+// senkrechten Weißraum-Streifen (Gutter), Leerzeichen aus dem Abstand
+// benachbarter Glyphen. Die E-Mail-Regexe laufen danach nur noch über diese
+// Tokens.
+
+const (
+	layoutLineTol     = 0.5  // Y-Toleranz innerhalb einer Zeile (× Schriftgröße)
+	layoutSpaceGap    = 0.15 // Abstand > … × Schriftgröße ⇒ Wortgrenze
+	layoutSegmentGap  = 1.2  // Abstand > … × Schriftgröße ⇒ neues Segment (Spalte, Tabelle)
+	layoutGutterShare = 0.08 // max. Anteil Zeilen, die den Gutter kreuzen dürfen
+	layoutMinLines    = 6    // Spaltenerkennung erst ab so vielen Zeilen
+	layoutGutterBins  = 1000 // max. Streifen über die Breite (Koordinaten stammen aus dem PDF)
+)
+
+type layoutGlyph struct {
+	x, y, w, size float64
+	s             string
+}
+
+type layoutSegment struct {
+	x0, x1 float64
+	size   float64 // Schriftgröße des ersten Glyphs
+	text   string
+}
+
+type layoutLine struct {
+	y    float64
+	segs []layoutSegment
+}
+
+// pageLayoutText liefert den rekonstruierten Seitentext ("" ohne Glyphen oder bei defektem Stream).
+func pageLayoutText(p pdf.Page) (text string) {
+	defer func() {
+		if recover() != nil { // ledongthuc/pdf panict bei kaputten Content-Streams
+			text = ""
+		}
+	}()
+	var glyphs []layoutGlyph
+	for _, t := range p.Content().Text {
+		if t.S == "" {
+			continue
+		}
+		g := layoutGlyph{x: t.X, y: t.Y, w: t.W, size: t.FontSize, s: t.S}
+		if g.size <= 0 {
+			g.size = 10
+		}
+		if g.w <= 0 { // Fonts ohne Breitenangabe
+			g.w = 0.5 * g.size * float64(utf8.RuneCountInString(t.S))
+		}
+		glyphs = append(glyphs, g)
+	}
+	if len(glyphs) == 0 {
+		return ""
+	}
+	return layoutReadingOrder(layoutLines(glyphs))
+}
+
+// layoutLines gruppiert Glyphen zu Zeilen (oben → unten) und Zeilen zu Segmenten.
+func layoutLines(glyphs []layoutGlyph) []layoutLine {
+	sort.SliceStable(glyphs, func(i, j int) bool {
+		if glyphs[i].y != glyphs[j].y {
+			return glyphs[i].y > glyphs[j].y
+		}
+		return glyphs[i].x < glyphs[j].x
+	})
+
+	var lines []layoutLine
+	var cur []layoutGlyph
+	refY, refSize := 0.0, 0.0
+	flush := func() {
+		if len(cur) > 0 {
+			lines = append(lines, layoutLine{y: refY, segs: layoutSegments(cur)})
+		}
+		cur = nil
+	}
+	for _, g := range glyphs {
+		// Hoch-/Tiefstellungen (Affiliations-Marker) bleiben in der Zeile
+		if len(cur) > 0 && refY-g.y > layoutLineTol*maxFloat(refSize, g.size) {
+			flush()
+		}
+		if len(cur) == 0 {
+			refY, refSize = g.y, g.size
+		}
+		cur = append(cur, g)
+	}
+	flush()
+	return lines
+}
+
+// layoutSegments sortiert eine Zeile nach X, trennt bei großen Lücken und setzt Leerzeichen.
+func layoutSegments(line []layoutGlyph) []layoutSegment {
+	sort.SliceStable(line, func(i, j int) bool { return line[i].x < line[j].x })
+
+	var segs []layoutSegment
+	var sb strings.Builder
+	seg := layoutSegment{x0: line[0].x, size: line[0].size}
+	prev := line[0]
+	sb.WriteString(prev.s)
+	for _, g := range line[1:] {
+		// doppelt gezeichnete Glyphen (Fake-Bold) überspringen
+		if g.s == prev.s && g.x-prev.x < 0.1*g.size {
+			continue
+		}
+		gap := g.x - (prev.x + prev.w)
+		size := maxFloat(prev.size, g.size)
+		switch {
+		case gap > layoutSegmentGap*size:
+			seg.x1, seg.text = prev.x+prev.w, strings.TrimSpace(sb.String())
+			segs = append(segs, seg)
+			sb.Reset()
+			seg = layoutSegment{x0: g.x, size: g.size}
+		case gap > layoutSpaceGap*size && !strings.HasSuffix(sb.String(), " ") && !strings.HasPrefix(g.s, " "):
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(g.s)
+		prev = g
+	}
+	seg.x1, seg.text = prev.x+prev.w, strings.TrimSpace(sb.String())
+	return append(segs, seg)
+}
+
+// layoutReadingOrder: bei erkanntem Gutter erst linke, dann rechte Spalte; Zeilen, die den
+// Gutter überspannen (Titel, Abbildungen), trennen die Spaltenblöcke.
+func layoutReadingOrder(lines []layoutLine) string {
+	var out []string
+	emit := func(segs []layoutSegment) {
+		parts := make([]string, 0, len(segs))
+		for _, s := range segs {
+			if s.text != "" {
+				parts = append(parts, s.text)
+			}
+		}
+		if len(parts) > 0 {
+			out = append(out, strings.Join(parts, " "))
+		}
+	}
+
+	gutter, ok := layoutGutter(lines)
+	if !ok {
+		for _, l := range lines {
+			emit(l.segs)
+		}
+		return strings.Join(out, "\n")
+	}
+
+	var left, right [][]layoutSegment
+	flush := func() {
+		for _, s := range left {
+			emit(s)
+		}
+		for _, s := range right {
+			emit(s)
+		}
+		left, right = nil, nil
+	}
+	for _, l := range lines {
+		var ls, rs []layoutSegment
+		spanning := false
+		for _, s := range l.segs {
+			switch {
+			case s.x1 <= gutter:
+				ls = append(ls, s)
+			case s.x0 >= gutter:
+				rs = append(rs, s)
+			default:
+				spanning = true
+			}
+		}
+		if spanning {
+			flush()
+			emit(l.segs)
+			continue
+		}
+		if len(ls) > 0 {
+			left = append(left, ls)
+		}
+		if len(rs) > 0 {
+			right = append(right, rs)
+		}
+	}
+	flush()
+	return strings.Join(out, "\n")
+}
+
+// layoutGutter sucht in der Seitenmitte (30–70 % der Breite) den breitesten senkrechten Streifen, den
+// (fast) keine Zeile kreuzt und links wie rechts davon genügend Zeilen Text haben.
+func layoutGutter(lines []layoutLine) (float64, bool) {
+	if len(lines) < layoutMinLines {
+		return 0, false
+	}
+	minX, maxX := lines[0].segs[0].x0, lines[0].segs[0].x1
+	var sizes []float64
+	for _, l := range lines {
+		for _, s := range l.segs {
+			minX = minFloat(minX, s.x0)
+			maxX = maxFloat(maxX, s.x1)
+		}
+	}
+	width := maxX - minX
+	if !(width >= 100) || math.IsInf(width, 0) { // auch NaN
+		return 0, false
+	}
+	// Streifen zu 1 pt, bei absurden Seitenbreiten gröber (feste Obergrenze)
+	scale := math.Max(1, width/layoutGutterBins)
+	bins := make([]int, int(width/scale)+1)
+	for _, l := range lines {
+		for _, s := range l.segs {
+			for b := int((s.x0 - minX) / scale); b <= int((s.x1-minX)/scale) && b < len(bins); b++ {
+				bins[b]++
+			}
+			sizes = append(sizes, s.size)
+		}
+	}
+	sort.Float64s(sizes)
+	em := sizes[len(sizes)/2] // typische Schriftgröße = Mindestbreite des Gutters
+
+	limit := int(layoutGutterShare * float64(len(lines)))
+	lo, hi := int(float64(len(bins)-1)*0.3), int(float64(len(bins)-1)*0.7)
+	bestStart, bestLen, runStart := -1, 0, -1
+	for b := lo; b <= hi+1; b++ {
+		if b <= hi && bins[b] <= limit {
+			if runStart < 0 {
+				runStart = b
+			}
+			continue
+		}
+		if runStart >= 0 && b-runStart > bestLen {
+			bestStart, bestLen = runStart, b-runStart
+		}
+		runStart = -1
+	}
+	if bestStart < 0 || float64(bestLen)*scale < em {
+		return 0, false
+	}
+	gutter := minX + (float64(bestStart)+float64(bestLen)/2)*scale
+
+	both := 0
+	for _, l := range lines {
+		hasL, hasR := false, false
+		for _, s := range l.segs {
+			hasL = hasL || s.x1 <= gutter
+			hasR = hasR || s.x0 >= gutter
+		}
+		if hasL && hasR {
+			both++
+		}
+	}
+	if both < maxInt(3, len(lines)/5) {
+		return 0, false
+	}
+	return gutter, true
+}
+
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxFloat(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// =================== PDF-Annotationen & Metadaten ===================
+//
+// Viele PDFs tragen mailto:-Links als URI-Annotation und Autor/Kontakt in den
+// Info- bzw. XMP-Metadaten. Beides wird vor dem Seitentext gelesen: Adressen
+// daraus sind eigene Kandidaten, und passt das Author-Feld zur gesuchten
+// Person, bekommen namensnahe Kandidaten des Dokuments einen Bonus.
+
+const (
+	pdfViaAnnotation = "annotation" // mailto:-Link-Annotation
+	pdfViaMetadata   = "metadata"   // Info-/XMP-Felder
+
+	pdfAuthorMatchBonus = 3       // Bonus für Kandidaten mit Namensbezug in Dokumenten der Person
+	maxXMPBytes         = 1 << 20 // XMP-Paket lesen bis …
+)
+
+var (
+	reXMPCreator      = regexp.MustCompile(`(?is)<dc:creator>(.*?)</dc:creator>`)
+	reXMPListItem     = regexp.MustCompile(`(?is)<rdf:li[^>]*>([^<]+)</rdf:li>`)
+	reXMPAuthor       = regexp.MustCompile(`(?is)<pdf:Author>([^<]+)</pdf:Author>|pdf:Author="([^"]+)"`)
+	reMetaAuthorSplit = regexp.MustCompile(`\s*(?:;|&|\band\b|\bund\b)\s*`)
+)
+
+// pdfMeta: Autoren, mailto:-Annotationen und Adressen aus den Metadaten.
+type pdfMeta struct {
+	Authors []string
+	Mailtos []string
+	Emails  []string
+}
+
+// readPDFMeta liest Info-Dictionary, XMP-Paket und die Link-Annotationen der geplanten
+// Seiten (bis zur Frist).
+func readPDFMeta(r *pdf.Reader, pages []int, deadline time.Time) (meta pdfMeta) {
+	defer func() {
+		_ = recover() // defekte Objekte: mit dem weitermachen, was bis dahin gelesen wurde
+	}()
+	trailer := r.Trailer()
+
+	// Info-Dictionary
+	info := trailer.Key("Info")
+	if author := info.Key("Author").Text(); author != "" {
+		meta.Authors = append(meta.Authors, splitPDFAuthors(author)...)
+	}
+	var fields []string
+	for _, k := range []string{"Title", "Subject", "Keywords", "Creator"} {
+		fields = append(fields, info.Key(k).Text())
+	}
+
+	// XMP (Root/Metadata)
+	if rc := trailer.Key("Root").Key("Metadata").Reader(); rc != nil {
+		b, _ := io.ReadAll(io.LimitReader(rc, maxXMPBytes))
+		rc.Close()
+		xmp := string(b)
+		if m := reXMPCreator.FindStringSubmatch(xmp); m != nil {
+			for _, li := range reXMPListItem.FindAllStringSubmatch(m[1], -1) {
+				meta.Authors = append(meta.Authors, splitPDFAuthors(li[1])...)
+			}
+		}
+		for _, m := range reXMPAuthor.FindAllStringSubmatch(xmp, -1) {
+			meta.Authors = append(meta.Authors, splitPDFAuthors(m[1]+m[2])...)
+		}
+		fields = append(fields, xmp)
+	}
+	for _, f := range fields {
+		meta.Emails = append(meta.Emails, reEmailNormal.FindAllString(f, -1)...)
+	}
+
+	// URI-Link-Annotationen
+	for _, i := range pages {
+		if time.Now().After(deadline) {
+			break
+		}
+		annots := r.Page(i).V.Key("Annots")
+		for j := 0; j < annots.Len(); j++ {
+			a := annots.Index(j).Key("A")
+			if a.Key("S").Name() != "URI" {
+				continue
+			}
+			uri := strings.TrimSpace(a.Key("URI").RawString())
+			if addr := extractAddressFromMailto(uri); addr != "" {
+				meta.Mailtos = append(meta.Mailtos, addr)
+			}
+		}
+	}
+	return meta
+}
+
+// localMatchesPerson: der Local-Part trägt Nach- oder Vornamen der Person – nur solche
+// Adressen bekommen den Autoren-Bonus (nicht Co-Autoren oder Sekretariate).
+func localMatchesPerson(email, first, last string) bool {
+	local, _ := splitEmail(asciiFold(strings.ToLower(email)))
+	plain := removeSeparators(local)
+	tokens := splitLocalTokens(local)
+	for _, n := range []string{last, first} {
+		n = asciiFold(strings.ToLower(n))
+		if len(n) >= 3 && strings.Contains(plain, n[:minInt(6, len(n))]) {
+			return true
+		}
+		for _, t := range tokens {
+			if n != "" && t == n {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// splitPDFAuthors: „A. Smith; B. Jones and C. Doe“ → einzelne Namen. Ein einzelnes Komma
+// gilt als „Nachname, Vorname“, mehrere Kommas trennen Autoren.
+func splitPDFAuthors(s string) []string {
+	var out []string
+	for _, p := range reMetaAuthorSplit.Split(strings.TrimSpace(s), -1) {
+		if strings.Count(p, ",") > 1 {
+			out = append(out, splitPDFAuthors(strings.ReplaceAll(p, ",", ";"))...)
+			continue
+		}
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// authorMatches: ein Autor enthält den Nachnamen und (falls bekannt) Vorname oder Initiale.
+func (m pdfMeta) authorMatches(first, last string) bool {
+	if last == "" {
+		return false
+	}
+	lastForms := nameSpellings(last)
+	firstForms := nameSpellings(first)
+	for _, a := range m.Authors {
+		tokens := strings.FieldsFunc(asciiFold(strings.ToLower(a)), func(r rune) bool {
+			return !(r >= 'a' && r <= 'z' || r == '-')
+		})
+		hasLast, hasFirst := false, first == ""
+		for _, t := range tokens {
+			for _, l := range lastForms {
+				hasLast = hasLast || t == l
+			}
+			for _, f := range firstForms {
+				hasFirst = hasFirst || t == f || (len(t) == 1 && f != "" && t[0] == f[0])
+			}
+		}
+		if hasLast && hasFirst {
+			return true
+		}
+	}
+	return false
+}
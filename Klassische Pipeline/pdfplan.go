@@ -0,0 +1,419 @@
+package main
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// =================== Seitenplanung ===================
+//
+// planPages scannt starr Kopf, Ende und jede k-te Seite. planPDFPages ordnet
+// die Seiten stattdessen nach Priorität, damit im Zeitbudget die
+// wahrscheinlichsten zuerst drankommen:
+//   - Lesezeichen: „Curriculum Vitae“, „Contact“, „About the author“ … und
+//     Einträge mit dem Nachnamen der Person (Tagungsbände)
+//   - Seitenlabels: römische Titelei, Anhang mit Präfix („A-1“)
+//   - Dokumenttyp: Paper (erste Seite zählt), Abschlussarbeit (Titelei und
+//     Nachspann), Sammelband (Lesezeichen der Beiträge)
+//   - Seitenlänge: leere Seiten entfallen, riesige Content-Streams ans Ende
+
+const (
+	docKindPaper  = "paper"
+	docKindThesis = "thesis"
+	docKindBook   = "book" // Tagungsband/Sammelband
+	docKindOther  = "other"
+
+	maxOutlineEntries   = 600       // Schutz gegen zyklische/riesige Outlines
+	maxNameTreeDepth    = 12        // Named Destinations
+	maxLetterLabelLen   = 8         // Buchstaben-Labels („aa“, „aaa“ …) höchstens so lang
+	paperMaxPages       = 25        // bis hierhin gilt ein Dokument ohne Thesis-Lesezeichen als Paper
+	thesisTailPages     = 10        // Nachspann einer Abschlussarbeit (Lebenslauf, Erklärung)
+	bookMinTopEntries   = 8         // so viele Lesezeichen oben → Sammelband
+	blankPageMaxBytes   = 40        // Content-Stream kürzer → leere Seite
+	hugePageStreamBytes = 1_500_000 // teuer zu parsen → ans Ende
+)
+
+// Prioritäten (höher = früher); Seiten ohne Priorität werden nicht gescannt.
+const (
+	prioContactBookmark = 100
+	prioPersonBookmark  = 95
+	prioFirstPage       = 90
+	prioFollowPage      = 80 // Seite nach einem Treffer-Lesezeichen
+	prioHead            = 70
+	prioTail            = 60
+	prioAppendix        = 50
+	prioSample          = 20
+
+	prioFrontMatterBonus = 10 // römisch nummerierte Titelei einer Arbeit
+	prioHugePagePenalty  = 40
+)
+
+var (
+	reContactBookmark = regexp.MustCompile(`(?i)\b(curriculum\s+vitae|cv|vita|lebenslauf|contact|kontakt|about\s+the\s+authors?|über\s+(den|die)\s+autor(in|en)?|authors?('|’)?\s*(addresses|information|biograph(y|ies))|biograph(y|ies)|autorenverzeichnis|list\s+of\s+(contributors|authors)|correspondence|impressum|publications?\s+of\s+the\s+author|eigene\s+publikationen)\b`)
+	reThesisBookmark  = regexp.MustCompile(`(?i)\b(declaration|erklärung|eidesstattliche|affidavit|acknowledge?ments?|danksagung|dissertation|thesis|abschlussarbeit|chapter|kapitel|zusammenfassung)\b`)
+	reAppendixMark    = regexp.MustCompile(`(?i)^\s*(appendix|anhang)\b`)
+	reRomanLabel      = regexp.MustCompile(`^[ivxlcdm]+$`)
+)
+
+// pdfOutlineEntry: Lesezeichen mit aufgelöster Zielseite (0 = unbekannt).
+type pdfOutlineEntry struct {
+	Title string
+	Page  int
+	Depth int
+}
+
+// planPDFPages liefert die Scan-Reihenfolge für die Seiten 1..total (Fallback: planPages);
+// total ist die volle Seitenzahl, gekürzt wird erst die fertige Reihenfolge (maxPagesHardCap).
+func planPDFPages(r *pdf.Reader, total int, last string) (order []int, kind string) {
+	defer func() {
+		if recover() != nil { // defekte Outline/Seitenbäume
+			order, kind = planPages(total), docKindOther
+		}
+	}()
+
+	outline := readPDFOutline(r, total)
+	labels := readPageLabels(r, total)
+	kind = classifyPDFDoc(total, outline)
+
+	prio := make([]int, total+1) // Index = Seitennummer
+	raise := func(p, v int) {
+		if p >= 1 && p <= total && v > prio[p] {
+			prio[p] = v
+		}
+	}
+
+	// Grundplan je Dokumenttyp
+	switch kind {
+	case docKindPaper:
+		raise(1, prioFirstPage+5)
+		raise(2, prioHead)
+		for i := total - 1; i <= total; i++ {
+			raise(i, prioTail) // Biographien am Ende (IEEE & Co.)
+		}
+		for i := 3; i <= total; i++ {
+			raise(i, prioSample)
+		}
+	case docKindThesis:
+		raise(1, prioFirstPage)
+		for i := 2; i <= minInt(4, total); i++ {
+			raise(i, prioHead)
+		}
+		for i := maxInt(1, total-thesisTailPages+1); i <= total; i++ {
+			raise(i, prioTail)
+		}
+	default:
+		for n, p := range planPages(total) {
+			switch {
+			case p == 1:
+				raise(p, prioFirstPage)
+			case p <= initialHeadPages:
+				raise(p, prioHead-n)
+			case p > total-initialTailPages:
+				raise(p, prioTail)
+			}
+		}
+	}
+	for i := initialHeadPages + 1; i <= total; i += sampleEveryK {
+		raise(i, prioSample)
+	}
+
+	// Lesezeichen
+	lastFolded := ""
+	if len(last) >= 3 {
+		lastFolded = asciiFold(strings.ToLower(last))
+	}
+	for i, e := range outline {
+		if e.Page == 0 {
+			continue
+		}
+		title := asciiFold(strings.ToLower(e.Title))
+		switch {
+		case reContactBookmark.MatchString(e.Title):
+			raise(e.Page, prioContactBookmark)
+			raise(e.Page+1, prioFollowPage)
+		case lastFolded != "" && containsWord(title, lastFolded):
+			raise(e.Page, prioPersonBookmark)
+			raise(e.Page+1, prioFollowPage)
+		case reAppendixMark.MatchString(e.Title):
+			end := total
+			if i+1 < len(outline) && outline[i+1].Page > e.Page {
+				end = outline[i+1].Page - 1
+			}
+			for p := e.Page; p <= end && p < e.Page+4; p++ {
+				raise(p, prioAppendix)
+			}
+		}
+	}
+
+	// Seitenlabels
+	for p := 1; p <= total && p < len(labels); p++ {
+		l := strings.ToLower(labels[p])
+		switch {
+		case kind == docKindThesis && reRomanLabel.MatchString(l) && prio[p] > 0:
+			prio[p] += prioFrontMatterBonus
+		case strings.IndexFunc(l, func(c rune) bool { return c >= 'a' && c <= 'z' }) == 0 && strings.ContainsAny(l, "0123456789"):
+			raise(p, prioAppendix) // „A-1“, „B3“
+		}
+	}
+
+	// Seitenlänge
+	for p := 1; p <= total; p++ {
+		if prio[p] == 0 || prio[p] >= prioContactBookmark {
+			continue
+		}
+		n, ok := pageStreamBytes(r.Page(p))
+		switch {
+		case ok && n < blankPageMaxBytes:
+			prio[p] = 0
+		case n > hugePageStreamBytes:
+			prio[p] -= prioHugePagePenalty
+		}
+	}
+
+	for p := 1; p <= total; p++ {
+		if prio[p] > 0 {
+			order = append(order, p)
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool { return prio[order[i]] > prio[order[j]] })
+	if len(order) > maxPagesHardCap {
+		order = order[:maxPagesHardCap]
+	}
+	if len(order) == 0 {
+		order = planPages(total)
+	}
+	return order, kind
+}
+
+// classifyPDFDoc: Dokumenttyp aus Seitenzahl und Lesezeichen.
+func classifyPDFDoc(total int, outline []pdfOutlineEntry) string {
+	thesisHints, top := 0, 0
+	for _, e := range outline {
+		if reThesisBookmark.MatchString(e.Title) {
+			thesisHints++
+		}
+		if e.Depth == 0 {
+			top++
+		}
+	}
+	switch {
+	case total <= paperMaxPages && thesisHints < 2:
+		return docKindPaper
+	case thesisHints >= 2:
+		return docKindThesis
+	case top >= bookMinTopEntries:
+		return docKindBook
+	}
+	return docKindOther
+}
+
+// containsWord: w steht als ganzes Wort in s (beides klein, ASCII-gefaltet).
+func containsWord(s, w string) bool {
+	for i := strings.Index(s, w); i >= 0; {
+		before := i == 0 || !isASCIILetter(s[i-1])
+		after := i+len(w) == len(s) || !isASCIILetter(s[i+len(w)])
+		if before && after {
+			return true
+		}
+		j := strings.Index(s[i+1:], w)
+		if j < 0 {
+			break
+		}
+		i += 1 + j
+	}
+	return false
+}
+
+func isASCIILetter(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
+
+// -------------------- Outline --------------------
+
+// readPDFOutline liest die Lesezeichen in Dokumentreihenfolge samt Zielseite.
+func readPDFOutline(r *pdf.Reader, total int) []pdfOutlineEntry {
+	root := r.Trailer().Key("Root")
+	first := root.Key("Outlines").Key("First")
+	if first.Kind() != pdf.Dict {
+		return nil
+	}
+	pages := pageIndex(r, total)
+
+	var out []pdfOutlineEntry
+	var walk func(item pdf.Value, depth int)
+	walk = func(item pdf.Value, depth int) {
+		for ; item.Kind() == pdf.Dict && len(out) < maxOutlineEntries; item = item.Key("Next") {
+			dest := item.Key("Dest")
+			if dest.IsNull() {
+				if a := item.Key("A"); a.Key("S").Name() == "GoTo" {
+					dest = a.Key("D")
+				}
+			}
+			out = append(out, pdfOutlineEntry{
+				Title: strings.TrimSpace(item.Key("Title").Text()),
+				Page:  destPage(root, dest, pages, total),
+				Depth: depth,
+			})
+			if depth < 8 {
+				walk(item.Key("First"), depth+1)
+			}
+		}
+	}
+	walk(first, 0)
+	return out
+}
+
+// pageIndex: Fingerabdruck des Seiten-Dictionarys → Seitennummer. Die Bibliothek gibt die
+// Objektnummern nicht heraus; die Textform eines Seiten-Dictionarys enthält aber die
+// Referenzen auf Contents/Parent und ist damit praktisch eindeutig.
+func pageIndex(r *pdf.Reader, total int) map[string]int {
+	idx := make(map[string]int, total)
+	for i := 1; i <= total; i++ {
+		if v := r.Page(i).V; !v.IsNull() {
+			if _, dup := idx[v.String()]; !dup {
+				idx[v.String()] = i
+			}
+		}
+	}
+	return idx
+}
+
+// destPage löst explizite und benannte Ziele auf (0 = unbekannt oder außerhalb von total).
+func destPage(root, dest pdf.Value, pages map[string]int, total int) int {
+	switch dest.Kind() {
+	case pdf.Name:
+		dest = root.Key("Dests").Key(dest.Name())
+	case pdf.String:
+		dest = lookupNameTree(root.Key("Names").Key("Dests"), dest.RawString(), 0)
+	}
+	if dest.Kind() == pdf.Dict {
+		dest = dest.Key("D")
+	}
+	if dest.Kind() != pdf.Array || dest.Len() == 0 {
+		return 0
+	}
+	target := dest.Index(0)
+	if target.Kind() == pdf.Integer { // Seitenindex statt Referenz (fehlerhafte Erzeuger)
+		if p := int(target.Int64()) + 1; p >= 1 && p <= total {
+			return p
+		}
+		return 0
+	}
+	if target.Kind() != pdf.Dict {
+		return 0
+	}
+	return pages[target.String()]
+}
+
+// lookupNameTree sucht key in einem PDF-Namensbaum (Names-Paare, Kids mit Limits).
+func lookupNameTree(node pdf.Value, key string, depth int) pdf.Value {
+	if node.Kind() != pdf.Dict || depth > maxNameTreeDepth {
+		return pdf.Value{}
+	}
+	names := node.Key("Names")
+	for i := 0; i+1 < names.Len(); i += 2 {
+		if names.Index(i).RawString() == key {
+			return names.Index(i + 1)
+		}
+	}
+	kids := node.Key("Kids")
+	for i := 0; i < kids.Len(); i++ {
+		kid := kids.Index(i)
+		if lim := kid.Key("Limits"); lim.Len() == 2 {
+			if key < lim.Index(0).RawString() || key > lim.Index(1).RawString() {
+				continue
+			}
+		}
+		if v := lookupNameTree(kid, key, depth+1); !v.IsNull() {
+			return v
+		}
+	}
+	return pdf.Value{}
+}
+
+// -------------------- Seitenlabels & Seitenlänge --------------------
+
+// readPageLabels liefert die Labels der Seiten 1..total (Index = Seitennummer, "" = keins).
+func readPageLabels(r *pdf.Reader, total int) []string {
+	nums := r.Trailer().Key("Root").Key("PageLabels").Key("Nums")
+	if nums.Len() < 2 {
+		return nil
+	}
+	labels := make([]string, total+1)
+	for i := 0; i+1 < nums.Len(); i += 2 {
+		start := maxInt(0, int(nums.Index(i).Int64()))
+		end := total
+		if i+2 < nums.Len() {
+			end = int(nums.Index(i + 2).Int64())
+		}
+		style := nums.Index(i + 1)
+		prefix := style.Key("P").Text()
+		base := 1
+		if st := style.Key("St"); st.Kind() == pdf.Integer {
+			base = int(st.Int64())
+		}
+		for p := start; p < end && p < total; p++ {
+			labels[p+1] = prefix + pageLabelNumber(style.Key("S").Name(), base+p-start)
+		}
+	}
+	return labels
+}
+
+func pageLabelNumber(style string, n int) string {
+	switch style {
+	case "D":
+		return strconv.Itoa(n)
+	case "r", "R":
+		s := romanNumeral(n)
+		if style == "R" {
+			return strings.ToUpper(s)
+		}
+		return s
+	case "a", "A":
+		if n <= 0 || (n-1)/26 >= maxLetterLabelLen { // /St stammt aus der Datei
+			return ""
+		}
+		s := strings.Repeat(string(rune('a'+(n-1)%26)), (n-1)/26+1)
+		if style == "A" {
+			return strings.ToUpper(s)
+		}
+		return s
+	}
+	return ""
+}
+
+func romanNumeral(n int) string {
+	if n <= 0 || n >= 4000 {
+		return ""
+	}
+	vals := []int{1000, 900, 500, 400, 100, 90, 50, 40, 10, 9, 5, 4, 1}
+	syms := []string{"m", "cm", "d", "cd", "c", "xc", "l", "xl", "x", "ix", "v", "iv", "i"}
+	var b strings.Builder
+	for i, v := range vals {
+		for n >= v {
+			b.WriteString(syms[i])
+			n -= v
+		}
+	}
+	return b.String()
+}
+
+// pageStreamBytes: Summe der (komprimierten) Content-Stream-Längen einer Seite, ohne zu dekodieren.
+func pageStreamBytes(p pdf.Page) (int64, bool) {
+	c := p.V.Key("Contents")
+	switch c.Kind() {
+	case pdf.Stream:
+		return c.Key("Length").Int64(), true
+	case pdf.Array:
+		var n int64
+		for i := 0; i < c.Len(); i++ {
+			n += c.Index(i).Key("Length").Int64()
+		}
+		return n, true
+	case pdf.Null:
+		return 0, true // Seite ohne Inhalt
+	}
+	return 0, false
+}
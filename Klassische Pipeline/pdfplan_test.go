@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// outlinePDF baut ein PDF mit pages Seiten (je eigener Content-Stream) und einem
+// Lesezeichen je Eintrag von bookmarks (Titel → Seite).
+func outlinePDF(t *testing.T, pages int, bookmarks map[string]int) *pdf.Reader {
+	t.Helper()
+	var titles []string
+	for title := range bookmarks {
+		titles = append(titles, title)
+	}
+
+	var buf bytes.Buffer
+	var offsets []int
+	obj := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+	pageObj := func(p int) int { return 4 + len(titles) + 2*(p-1) }
+
+	buf.WriteString("%PDF-1.4\n")
+	obj("<< /Type /Catalog /Pages 2 0 R /Outlines 3 0 R >>") // 1
+	kids := ""
+	for p := 1; p <= pages; p++ {
+		kids += fmt.Sprintf("%d 0 R ", pageObj(p))
+	}
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", kids, pages)) // 2
+	if len(titles) == 0 {
+		obj("<< /Type /Outlines /Count 0 >>") // 3
+	} else {
+		obj(fmt.Sprintf("<< /Type /Outlines /First 4 0 R /Last %d 0 R /Count %d >>", 3+len(titles), len(titles)))
+	}
+	for i, title := range titles {
+		links := ""
+		if i > 0 {
+			links += fmt.Sprintf(" /Prev %d 0 R", 3+i)
+		}
+		if i+1 < len(titles) {
+			links += fmt.Sprintf(" /Next %d 0 R", 5+i)
+		}
+		obj(fmt.Sprintf("<< /Title (%s) /Parent 3 0 R /Dest [%d 0 R /Fit]%s >>", title, pageObj(bookmarks[title]), links))
+	}
+	for p := 1; p <= pages; p++ {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 595 842] /Contents %d 0 R >>", pageObj(p)+1))
+		text := fmt.Sprintf("BT /F1 12 Tf 72 720 Td (Seite %d mit etwas Fliesstext) Tj ET", p)
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(text), text))
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	r, err := pdf.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Test-PDF: %v", err)
+	}
+	return r
+}
+
+func TestPlanPDFPages(t *testing.T) {
+	tests := []struct {
+		name      string
+		pages     int
+		bookmarks map[string]int
+		last      string
+		wantHead  []int
+		wantKind  string
+	}{
+		// Lebenslauf hinter dem früheren 120-Seiten-Cap
+		{"Lebenslauf auf Seite 190", 200, map[string]int{"Curriculum Vitae": 190}, "", []int{190, 1, 191}, docKindOther},
+		{"Beitrag mit Nachnamen", 200, map[string]int{"J. Doe: Graph Methods": 150}, "Doe", []int{150, 1, 151}, docKindOther},
+		{"Paper ohne Lesezeichen", 6, nil, "", []int{1, 2, 5, 6, 3, 4}, docKindPaper},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := outlinePDF(t, tt.pages, tt.bookmarks)
+			order, kind := planPDFPages(r, r.NumPage(), tt.last)
+			if kind != tt.wantKind {
+				t.Errorf("kind = %q, erwartet %q", kind, tt.wantKind)
+			}
+			if len(order) < len(tt.wantHead) || !reflect.DeepEqual(order[:len(tt.wantHead)], tt.wantHead) {
+				t.Errorf("order = %v, erwartet Beginn %v", order, tt.wantHead)
+			}
+			if len(order) > maxPagesHardCap {
+				t.Errorf("len(order) = %d > %d", len(order), maxPagesHardCap)
+			}
+		})
+	}
+}
+
+func TestPageLabelNumber(t *testing.T) {
+	tests := []struct {
+		style string
+		n     int
+		want  string
+	}{
+		{"D", 12, "12"},
+		{"r", 4, "iv"},
+		{"R", 1999, "MCMXCIX"},
+		{"r", 0, ""},
+		{"a", 1, "a"},
+		{"A", 28, "BB"},
+		{"a", 0, ""},
+		{"a", -5, ""},
+		{"a", 1 << 40, ""}, // /St aus einer präparierten Datei
+		{"x", 3, ""},
+	}
+	for _, tt := range tests {
+		if got := pageLabelNumber(tt.style, tt.n); got != tt.want {
+			t.Errorf("pageLabelNumber(%q, %d) = %q, erwartet %q", tt.style, tt.n, got, tt.want)
+		}
+	}
+}
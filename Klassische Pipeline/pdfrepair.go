@@ -0,0 +1,107 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// =================== Umbrochene Adressen reparieren ===================
+//
+// In PDFs werden Adressen oft über Zeilen getrennt: „klemens.mus-⏎ter@uni.de“
+// oder „name@cs.uni-⏎stuttgart.de“. reEmailFragmented toleriert nur Leerraum
+// um das @. Hier wird das letzte Token einer Zeile mit dem ersten der
+// nächsten verbunden, wenn der Umbruch an einer plausiblen Stelle liegt
+// (nach - . _ @ oder vor @ .) und erst die Verbindung eine Adresse ergibt.
+
+// Herkunft reparierter Kandidaten (landet in der Provenienz des PDF-Treffers).
+const (
+	pdfViaRepairHyphen    = "repaired:hyphen"    // Trennstrich entfernt
+	pdfViaRepairLinebreak = "repaired:linebreak" // Zeilen ohne Änderung verbunden
+)
+
+var (
+	reTailEmailRun = regexp.MustCompile(`(?i)[a-z0-9._%+\-@]+$`)
+	reHeadEmailRun = regexp.MustCompile(`(?i)^[a-z0-9._%+\-@]+`)
+)
+
+// repairedEmail ist ein aus zwei Zeilen zusammengesetzter Kandidat.
+type repairedEmail struct {
+	Email string
+	Via   string
+}
+
+// repairWrappedEmails schlägt Adressen vor, die über einen Zeilenumbruch verteilt sind.
+// Erwartet Text mit erhaltenen Zeilenumbrüchen (vor der Whitespace-Normalisierung).
+func repairWrappedEmails(text string) []repairedEmail {
+	lines := strings.Split(text, "\n")
+	var out []repairedEmail
+	seen := map[string]struct{}{}
+	add := func(e, via string) {
+		if _, ok := seen[e]; !ok {
+			seen[e] = struct{}{}
+			out = append(out, repairedEmail{Email: e, Via: via})
+		}
+	}
+
+	for i := 0; i+1 < len(lines); i++ {
+		tail := reTailEmailRun.FindString(strings.TrimRight(lines[i], " \t\r"))
+		head := reHeadEmailRun.FindString(strings.TrimLeft(lines[i+1], " \t\r"))
+		if tail == "" || head == "" || !plausibleWrap(tail, head) {
+			continue
+		}
+		if strings.HasSuffix(tail, "-") {
+			// Im Local-Part ist der Strich meist Silbentrennung, in Domains meist echt
+			dehy := strings.TrimSuffix(tail, "-")
+			joinedDehy := crossingEmail(dehy, head)
+			joinedKeep := crossingEmail(tail, head)
+			if strings.Contains(tail, "@") {
+				if joinedKeep != "" {
+					add(joinedKeep, pdfViaRepairLinebreak)
+				}
+				if joinedDehy != "" {
+					add(joinedDehy, pdfViaRepairHyphen)
+				}
+			} else {
+				if joinedDehy != "" {
+					add(joinedDehy, pdfViaRepairHyphen)
+				}
+				if joinedKeep != "" {
+					add(joinedKeep, pdfViaRepairLinebreak)
+				}
+			}
+			continue
+		}
+		if e := crossingEmail(tail, head); e != "" {
+			add(e, pdfViaRepairLinebreak)
+		}
+	}
+	return out
+}
+
+// plausibleWrap: Umbruch nach/vor einem Trenner oder mitten in einer noch unvollständigen Domain.
+func plausibleWrap(tail, head string) bool {
+	// „… baruah@cs.unc.edu.“ ⏎ „The …“: Satzende nach vollständiger Adresse
+	if t := strings.TrimRight(tail, "."); t != tail {
+		if loc := reEmailNormal.FindStringIndex(t); loc != nil && loc[1] == len(t) {
+			return false
+		}
+	}
+	if strings.ContainsAny(tail[len(tail)-1:], "-._@") || strings.ContainsAny(head[:1], "@.") {
+		return true
+	}
+	if at := strings.LastIndexByte(tail, '@'); at >= 0 && !strings.Contains(tail[at:], ".") {
+		return true // „name@uni“ ⏎ „stuttgart.de“
+	}
+	return false
+}
+
+// crossingEmail: Adresse in tail+head, die über die Nahtstelle reicht (sonst "").
+func crossingEmail(tail, head string) string {
+	joined := tail + head
+	for _, loc := range reEmailNormal.FindAllStringIndex(joined, -1) {
+		if loc[0] < len(tail) && loc[1] > len(tail) {
+			return sanitizeEmailTight(joined[loc[0]:loc[1]])
+		}
+	}
+	return ""
+}
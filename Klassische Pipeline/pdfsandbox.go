@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"Bachelorprojekt/internal/pdfsandbox"
+)
+
+// =================== Sandbox für PDF-Worker ===================
+//
+// GOMEMLIMIT ist nur ein GC-Ziel: ein defektes oder bösartiges PDF kann den
+// Worker trotzdem aufblähen oder die CPU festhalten. Unter Linux setzt sich
+// der Worker deshalb vor dem ersten Auftrag harte rlimits (Adressraum,
+// CPU-Zeit je Auftrag, offene Dateien), arbeitet in einem privaten
+// Temp-Verzeichnis und kann optional einen seccomp-Filter laden (kein
+// Netzwerk, kein exec, kein ptrace). Verstöße meldet der Pool als eigene
+// Fehlerarten. Die Plattformteile liegen in internal/pdfsandbox.
+
+var (
+	errPDFWorkerMemLimit  = errors.New("pdf-worker: Speicherlimit überschritten")
+	errPDFWorkerCPULimit  = errors.New("pdf-worker: CPU-Limit überschritten")
+	errPDFWorkerFileLimit = errors.New("pdf-worker: Limit offener Dateien erreicht")
+	errPDFWorkerSandbox   = errors.New("pdf-worker: von der Sandbox blockiert")
+)
+
+// workerLimitsFromConfig übersetzt die Laufkonfiguration für das Child.
+func workerLimitsFromConfig() pdfsandbox.Limits {
+	return pdfsandbox.Limits{
+		AddressSpaceMB: runCfg.PDFWorkerASMB,
+		CPUPerJobSec:   int((runCfg.PDFWorkerCPU + time.Second - 1) / time.Second),
+		MaxJobs:        runCfg.PDFWorkerMaxJobs,
+		NoFile:         runCfg.PDFWorkerNoFile,
+		Seccomp:        runCfg.PDFWorkerSeccomp,
+	}
+}
+
+// classifyWorkerExit ordnet das Ende eines Workers einer Fehlerart zu.
+func classifyWorkerExit(state *os.ProcessState, stderr *tailBuffer, cpuHardSec int) error {
+	if state == nil {
+		return errPDFWorkerCrashed
+	}
+	reason := stderr.reason()
+	if err := classifyWorkerMessage(reason, runCfg.PDFWorkerSeccomp); err != nil {
+		return err
+	}
+	switch state.ExitCode() {
+	case pdfsandbox.ExitCPU:
+		return errPDFWorkerCPULimit
+	case pdfsandbox.ExitSetup:
+		return fmt.Errorf("%w: %s", errPDFWorkerSandbox, reason)
+	}
+	// hartes CPU-Limit: Kernel schickt SIGKILL
+	if cpuHardSec > 0 && state.UserTime()+state.SystemTime() >= time.Duration(cpuHardSec-1)*time.Second {
+		return errPDFWorkerCPULimit
+	}
+	if reason != "" {
+		return fmt.Errorf("%w: %s", errPDFWorkerCrashed, reason)
+	}
+	return fmt.Errorf("%w: %s", errPDFWorkerCrashed, state)
+}
+
+// classifyWorkerMessage erkennt Limit-Verstöße an Fehlertexten des Workers (nil = keiner).
+// EPERM gilt nur mit aktivem seccomp-Filter als Sandbox-Verstoß; sonst ist es ein
+// gewöhnlicher Dateifehler (z. B. fehlende Leserechte).
+func classifyWorkerMessage(msg string, seccomp bool) error {
+	m := strings.ToLower(msg)
+	switch {
+	case strings.Contains(m, "out of memory") || strings.Contains(m, "cannot allocate memory"):
+		return errPDFWorkerMemLimit
+	case strings.Contains(m, "cpu limit exceeded"):
+		return errPDFWorkerCPULimit
+	case strings.Contains(m, "too many open files"):
+		return errPDFWorkerFileLimit
+	case seccomp && (strings.Contains(m, "operation not permitted") || strings.Contains(m, "bad system call")):
+		return errPDFWorkerSandbox
+	}
+	return nil
+}
+
+// tailBuffer behält das Ende der Worker-Ausgabe auf stderr und die erste
+// „fatal error:“/„panic:“-Zeile (Runtime-Abbrüche drucken danach lange Stacks).
+type tailBuffer struct {
+	buf   []byte
+	max   int
+	fatal []byte // ab dem ersten Abbruch-Marker, max. fatalKeep Bytes
+	carry []byte // Ende des letzten Writes (Marker über Write-Grenzen)
+}
+
+const fatalKeep = 512
+
+var fatalMarkers = [][]byte{[]byte("fatal error:"), []byte("panic:")}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	switch {
+	case t.fatal != nil:
+		if room := fatalKeep - len(t.fatal); room > 0 {
+			t.fatal = append(t.fatal, p[:minInt(room, len(p))]...)
+		}
+	default:
+		s := append(t.carry, p...)
+		for _, m := range fatalMarkers {
+			if i := bytes.Index(s, m); i >= 0 {
+				t.fatal = append([]byte(nil), s[i:minInt(len(s), i+fatalKeep)]...)
+				break
+			}
+		}
+		t.carry = append([]byte(nil), s[maxInt(0, len(s)-16):]...)
+	}
+	t.buf = append(t.buf, p...)
+	if len(t.buf) > t.max {
+		t.buf = t.buf[len(t.buf)-t.max:]
+	}
+	return len(p), nil
+}
+
+// reason: Abbruchmeldung, sonst die letzte Zeile der Ausgabe.
+func (t *tailBuffer) reason() string {
+	if t.fatal != nil {
+		return strings.TrimSpace(strings.SplitN(string(t.fatal), "\n", 2)[0])
+	}
+	lines := strings.Split(strings.TrimSpace(string(t.buf)), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
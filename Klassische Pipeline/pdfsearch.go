@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 )
@@ -26,6 +27,7 @@ const (
 	perPageTextLimitBytes = 256 * 1024 // Textlimit pro Seite
 	highConfidenceCutoff  = 14         // Early-Exit ab Score
 	maxCandidatesToScore  = 80         // max. Kandidaten scoren
+	docTextBudgetBytes    = 1_200_000  // gesamtes Textbudget je Dokument
 )
 
 // =================== Regex ===================
@@ -33,7 +35,6 @@ const (
 var (
 	reEmailNormal     = regexp.MustCompile(`(?i)\b[a-z0-9._%+\-]{1,64}@[a-z0-9.\-]{3,}\.[a-z]{2,}\b`)
 	reEmailFragmented = regexp.MustCompile(`(?i)([a-z0-9._%+\-]{1,64})\s*\n?\s*@\s*\n?\s*([a-z0-9.\-]{1,200}\.[a-z]{2,})`)
-	reEmailSymbolic   = regexp.MustCompile(`(?i)([a-z0-9._+\-]{1,64})\s*(?:\(|\[)?\s*(?:@|at)\s*(?:\)|\])?\s*([a-z0-9.\-\s\[\]\(\)]+)`)
 	reNoiseSpaces     = regexp.MustCompile(`\s+`)
 	reLocalOK         = regexp.MustCompile(`^[a-z0-9._+\-]{1,64}$`)
 	reLabelOK         = regexp.MustCompile(`^[a-z0-9-]+$`)
@@ -42,122 +43,157 @@ var (
 // =================== Download with size limit ===================
 
 func DownloadPDF(u string, filename string) error {
+	_, err := DownloadDocument(u, filename)
+	return err
+}
+
+// DownloadDocument lädt ein Dokument (PDF, DOCX, … siehe docextract.go) und liefert den Content-Type.
+func DownloadDocument(u string, filename string) (string, error) {
+	if err := robotsCheck(u, "PDF"); err != nil {
+		return "", err
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), pdfHTTPTimeout)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
 	if err != nil {
-		return err
+		return "", err
 	}
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64)")
-	req.Header.Set("Accept", "application/pdf,application/octet-stream;q=0.9,*/*;q=0.8")
+	req.Header.Set("Accept", "application/pdf,application/vnd.openxmlformats-officedocument.*,application/vnd.oasis.opendocument.*,application/rtf,application/octet-stream;q=0.9,*/*;q=0.8")
 	req.Header.Set("Accept-Language", "en-US,en;q=0.9,de;q=0.8")
 
-	client := &http.Client{Timeout: pdfHTTPTimeout}
+	client := cachedClient(cacheKindPDF, pdfHTTPTimeout)
 	resp, err := client.Do(req)
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer resp.Body.Close()
 
 	// Content-Length Vorprüfung gegen Monster-PDFs
 	if cl := resp.ContentLength; cl > 0 && cl > maxPDFBytes {
-		return errors.New("skip large PDF (content-length)")
+		return "", errors.New("skip large document (content-length)")
 	}
 
 	// tolerant: viele Server liefern octet-stream
-	ct := strings.ToLower(resp.Header.Get("Content-Type"))
-	if ct != "" && !(strings.HasPrefix(ct, "application/pdf") || strings.HasPrefix(ct, "application/octet-stream")) {
-		return errors.New("not a PDF response")
+	ct := resp.Header.Get("Content-Type")
+	if !documentContentTypeOK(ct) {
+		return "", errors.New("not a document response")
 	}
 
 	out, err := os.Create(filename)
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer out.Close()
 
 	// Hartes Download-Limit
 	_, err = io.Copy(out, io.LimitReader(resp.Body, maxPDFBytes))
-	return err
+	return ct, err
 }
 
 // =================== PDF email extraction ===================
 
+// Herkunft eines PDF-Kandidaten (Provenienz, siehe auch pdfrepair.go).
+const (
+	pdfViaText       = "text"
+	pdfViaGrouped    = "grouped"
+	pdfViaFragmented = "fragmented"
+	pdfViaSymbolic   = "symbolic"
+)
+
+// pdfCandidate: ein bewerteter Kandidat eines PDFs.
+type pdfCandidate struct {
+	Email string `json:"email"`
+	Score int    `json:"score"`
+	Via   string `json:"via"`
+}
+
+// pdfScanResult: bester Kandidat eines PDFs samt Herkunft, dazu alle bewerteten Kandidaten.
+type pdfScanResult struct {
+	Email      string
+	Score      int
+	Via        string
+	Candidates []pdfCandidate // absteigend nach Score
+	Harvest    docHarvest     // personenunabhängig, für den Dokument-Store (docstore.go)
+}
+
+// docHarvest: was ein Scan unabhängig von der gesuchten Person gefunden hat.
+type docHarvest struct {
+	Addresses []pdfCandidate  `json:"addresses"` // Fundreihenfolge, Score 0
+	Authors   []string        `json:"authors,omitempty"`
+	Block     *pdfAuthorBlock `json:"block,omitempty"`
+	Complete  bool            `json:"complete"` // alle Seiten gelesen, ohne Early-Exit/Budgetende
+}
+
 // Context-fähige Analyse (im Worker aufgerufen)
 func ExtractEmailsFromPDFCtx(ctx context.Context, path string, person string) (string, int, error) {
+	res, err := scanPDFCandidates(ctx, path, person)
+	return res.Email, res.Score, err
+}
+
+// scanPDFCandidates wie ExtractEmailsFromPDFCtx, liefert zusätzlich die Herkunft des Treffers.
+// Ohne Person (Indizierung des lokalen Korpus, corpus.go) werden alle Seiten der Reihe nach
+// gelesen, ohne Seiten-, Kandidaten- und Textlimit; es gilt nur die Frist von ctx.
+func scanPDFCandidates(ctx context.Context, path string, person string) (pdfScanResult, error) {
+	indexing := strings.TrimSpace(person) == ""
 	deadline := time.Now().Add(pdfTimeBudget)
-	if dl, ok := ctx.Deadline(); ok && dl.Before(deadline) {
+	if dl, ok := ctx.Deadline(); ok && (dl.Before(deadline) || indexing) {
 		deadline = dl
 	}
 
 	f, r, err := pdf.Open(path)
 	if err != nil {
-		return "", 0, err
+		return pdfScanResult{}, err
 	}
 	defer f.Close()
 
 	total := r.NumPage()
 	if total <= 0 {
-		return "", 0, nil
+		return pdfScanResult{}, nil
 	}
-	if total > maxPagesHardCap {
-		total = maxPagesHardCap
+
+	// Reihenfolge nach Lesezeichen, Seitenlabels, Dokumenttyp und Seitenlänge (pdfplan.go);
+	// geplant wird über das ganze Dokument, gescannt werden höchstens maxPagesHardCap Seiten
+	var pages []int
+	if indexing {
+		pages = make([]int, total)
+		for i := range pages {
+			pages[i] = i + 1
+		}
+	} else {
+		_, _, last, _ := splitNameAndOrgNoLists(person)
+		pages, _ = planPDFPages(r, total, last)
 	}
 
-	pages := planPages(total)
-	first, middle, last, org := splitNameAndOrgNoLists(person)
+	// Annotationen (nur geplante Seiten) & Metadaten (Author-Feld → gehört das Dokument der Person?)
+	meta := readPDFMeta(r, pages, deadline)
+	sc := newDocScorer(person, meta, deadline)
 
 	const (
-		perPageTimeBudget  = 800 * time.Millisecond // hartes Limit pro Seite
-		docTextBudgetBytes = 1_200_000              // gesamtes Textbudget
-		maxTimeoutStrikes  = 2                      // max. Seiten-Timeouts, bevor wir abbrechen
+		perPageTimeBudget = 800 * time.Millisecond // hartes Limit pro Seite
+		maxTimeoutStrikes = 2                      // max. Seiten-Timeouts, bevor wir abbrechen
 	)
 
 	var (
-		bestEmail string
-		bestScore = -1
-		seen      = make(map[string]struct{})
-		scored    = 0
 		usedBytes = 0
 		strikes   = 0
+		read      = make(map[int]bool, len(pages)) // gelesene Seiten (Vollständigkeit des Harvests)
 	)
 
-	consider := func(raw string) bool {
-		email := sanitizeEmailTight(raw)
-		if email == "" {
-			return false
-		}
-		at := strings.LastIndexByte(email, '@')
-		if at <= 0 {
-			return false
-		}
-		domain := strings.ToLower(strings.TrimSpace(email[at+1:]))
-		if !validDomain(domain) {
-			return false
-		}
-		if _, ok := seen[email]; ok {
-			return false
-		}
-		seen[email] = struct{}{}
-
-		score := getScoreOrgGeneral(strings.ToLower(email), first, middle, last, org)
-		if score > bestScore {
-			bestScore = score
-			bestEmail = email
-		}
-		scored++
-		return score >= highConfidenceCutoff
-	}
-
-	// Seitentext mit hartem Timeout holen
-	getPlainTextWithTimeout := func(p pdf.Page, d time.Duration) (string, error) {
+	// Seitentext mit hartem Timeout holen (layout-bewusst, sonst GetPlainText)
+	getPageTextWithTimeout := func(p pdf.Page, d time.Duration) (string, error) {
 		type result struct {
 			txt string
 			err error
 		}
 		ch := make(chan result, 1)
 		go func() {
+			if txt := pageLayoutText(p); txt != "" {
+				ch <- result{txt: txt}
+				return
+			}
 			txt, err := p.GetPlainText(nil)
 			ch <- result{txt: txt, err: err}
 		}()
@@ -171,26 +207,31 @@ func ExtractEmailsFromPDFCtx(ctx context.Context, path string, person string) (s
 		}
 	}
 
+	if sc.scanMeta(meta) {
+		return sc.result(), nil
+	}
+
 	for _, i := range pages {
-		if time.Now().After(deadline) || scored >= maxCandidatesToScore {
-			break
-		}
-		if time.Until(deadline) < 200*time.Millisecond {
+		if sc.exhausted() || time.Until(deadline) < 200*time.Millisecond {
+			sc.truncated = true
 			break
 		}
 
 		p := r.Page(i)
 		if p.V.IsNull() {
+			read[i] = true
 			continue
 		}
-		txt, err := getPlainTextWithTimeout(p, perPageTimeBudget)
+		txt, err := getPageTextWithTimeout(p, perPageTimeBudget)
 		if err == context.DeadlineExceeded {
 			strikes++
 			if strikes > maxTimeoutStrikes {
+				sc.truncated = true
 				break
 			}
 			continue
 		}
+		read[i] = true
 		if err != nil || len(txt) == 0 {
 			continue
 		}
@@ -200,52 +241,239 @@ func ExtractEmailsFromPDFCtx(ctx context.Context, path string, person string) (s
 		}
 
 		usedBytes += len(txt)
-		if usedBytes > docTextBudgetBytes {
+		if usedBytes > docTextBudgetBytes && !indexing {
+			sc.truncated = true
 			break
 		}
 
-		txt = strings.ReplaceAll(txt, "\u00a0", " ")
-		txt = reNoiseSpaces.ReplaceAllString(txt, " ")
+		// Autorenblock nur auf den ersten Seiten
+		if sc.scanText(txt, i <= 2) {
+			break
+		}
+	}
+	// Stichprobe oder Seiten-Cap → Harvest gilt nicht als vollständig
+	if !sc.early && !sc.truncated && !pdfAllPagesRead(r, read) {
+		sc.truncated = true
+	}
+	return sc.result(), nil
+}
 
-		if !pageLikelyHasEmailHint(txt) {
+// pdfAllPagesRead: jede Seite des Dokuments wurde gelesen oder ist leer.
+func pdfAllPagesRead(r *pdf.Reader, read map[int]bool) (all bool) {
+	defer func() {
+		if recover() != nil { // defekter Seitenbaum → nicht vollständig
+			all = false
+		}
+	}()
+	for p := 1; p <= r.NumPage(); p++ {
+		if read[p] {
 			continue
 		}
+		if n, ok := pageStreamBytes(r.Page(p)); !ok || n >= blankPageMaxBytes {
+			return false
+		}
+	}
+	return true
+}
 
-		// 1) einfache E-Mails
-		for _, m := range reEmailNormal.FindAllString(txt, -1) {
-			if consider(m) {
-				return bestEmail, bestScore, nil
-			}
-			if time.Now().After(deadline) || scored >= maxCandidatesToScore {
-				break
+// =================== Kandidaten-Bewertung je Dokument ===================
+
+// docScorer sammelt und bewertet die Kandidaten eines Dokuments (PDF-Seiten oder der Text
+// anderer Formate, siehe docextract.go).
+type docScorer struct {
+	first, middle, last, org string
+
+	authorMatch bool            // Dokument-Autor = gesuchte Person (Metadaten)
+	authors     *pdfAuthorBlock // Autorenblock der ersten Seiten (falls erkannt)
+	target      int             // Index der Zielperson im Autorenblock
+
+	deadline time.Time
+	seen     map[string]struct{}
+	cands    []pdfCandidate
+	scored   int
+
+	meta      pdfMeta
+	found     []pdfCandidate // alle gültigen Adressen in Fundreihenfolge (Harvest)
+	foundSet  map[string]struct{}
+	early     bool // Early-Exit ausgelöst
+	indexing  bool // ohne Person: alles ernten, kein Kandidatenlimit, kein Early-Exit
+	truncated bool // Zeit-/Textbudget erschöpft oder nicht alle Seiten gelesen
+
+	bestEmail string
+	bestScore int
+	bestVia   string
+}
+
+func newDocScorer(person string, meta pdfMeta, deadline time.Time) *docScorer {
+	s := &docScorer{target: -1, deadline: deadline, seen: map[string]struct{}{}, bestScore: -1, meta: meta, foundSet: map[string]struct{}{}}
+	s.first, s.middle, s.last, s.org = splitNameAndOrgNoLists(person)
+	s.indexing = strings.TrimSpace(person) == ""
+	s.authorMatch = meta.authorMatches(s.first, s.last)
+	return s
+}
+
+// consider bewertet einen Rohkandidaten; true = sicher genug für den Abbruch.
+func (s *docScorer) consider(raw, via string) bool {
+	email := sanitizeEmailTight(raw)
+	if email == "" {
+		return false
+	}
+	at := strings.LastIndexByte(email, '@')
+	if at <= 0 {
+		return false
+	}
+	domain := strings.ToLower(strings.TrimSpace(email[at+1:]))
+	if !validDomain(domain) {
+		return false
+	}
+	if _, ok := s.seen[email]; ok {
+		return false
+	}
+	s.seen[email] = struct{}{}
+	s.record(email, via)
+
+	score := getScoreOrgGeneral(strings.ToLower(email), s.first, s.middle, s.last, s.org)
+	if s.authorMatch && score > 0 && localMatchesPerson(email, s.first, s.last) {
+		score = minInt(score+pdfAuthorMatchBonus, 20)
+	}
+	score = s.authors.adjustScore(email, score, s.target)
+	s.cands = append(s.cands, pdfCandidate{Email: email, Score: score, Via: via})
+	if score > s.bestScore {
+		s.bestScore = score
+		s.bestEmail = email
+		s.bestVia = via
+	}
+	s.scored++
+	hit := score >= highConfidenceCutoff && !s.indexing
+	if hit {
+		s.early = true
+	}
+	return hit
+}
+
+// record merkt eine Adresse für den Harvest (unabhängig von Score und Early-Exit).
+func (s *docScorer) record(email, via string) {
+	if _, ok := s.foundSet[email]; ok {
+		return
+	}
+	s.foundSet[email] = struct{}{}
+	s.found = append(s.found, pdfCandidate{Email: email, Via: via})
+}
+
+// harvestText merkt alle einfachen und gruppierten Adressen einer Seite, auch wenn
+// das Scoring vorher per Early-Exit endet (Co-Autoren im selben Kopf).
+func (s *docScorer) harvestText(txt string) {
+	add := func(raw, via string) {
+		if email := sanitizeEmailTight(raw); email != "" {
+			if at := strings.LastIndexByte(email, '@'); at > 0 && validDomain(strings.ToLower(email[at+1:])) {
+				s.record(email, via)
 			}
 		}
-		// 2) fragmentierte
-		for _, m := range reEmailFragmented.FindAllStringSubmatch(txt, -1) {
-			if len(m) >= 3 {
-				if consider(m[1] + "@" + m[2]) {
-					return bestEmail, bestScore, nil
-				}
-			}
-			if time.Now().After(deadline) || scored >= maxCandidatesToScore {
-				break
-			}
+	}
+	for _, m := range expandGroupedEmails(txt) {
+		add(m, pdfViaGrouped)
+	}
+	for _, m := range reEmailNormal.FindAllString(txt, -1) {
+		add(m, pdfViaText)
+	}
+}
+
+func (s *docScorer) exhausted() bool {
+	return time.Now().After(s.deadline) || (!s.indexing && s.scored >= maxCandidatesToScore)
+}
+
+// scanMeta: mailto:-Links und Adressen aus den Metadaten vor dem Text.
+func (s *docScorer) scanMeta(meta pdfMeta) bool {
+	for _, m := range meta.Mailtos {
+		if s.consider(m, pdfViaAnnotation) {
+			return true
 		}
-		// 3) symbolische
-		for _, m := range extractSymbolicEmailsFromText(txt) {
-			if consider(m) {
-				return bestEmail, bestScore, nil
-			}
-			if time.Now().After(deadline) || scored >= maxCandidatesToScore {
-				break
+	}
+	for _, m := range meta.Emails {
+		if s.consider(m, pdfViaMetadata) {
+			return true
+		}
+	}
+	return false
+}
+
+// scanText durchsucht eine Seite bzw. einen Dokumenttext; head = Autorenblock suchen.
+func (s *docScorer) scanText(txt string, head bool) bool {
+	// Autorenblock (vor der Whitespace-Normalisierung)
+	if s.authors == nil && head {
+		if s.authors = parsePDFAuthorBlock(txt); s.authors != nil {
+			s.target = s.authors.targetIndex(s.first, s.last)
+		}
+	}
+
+	// über Zeilen getrennte Adressen (braucht die Zeilenumbrüche)
+	repaired := repairWrappedEmails(strings.ReplaceAll(txt, "\u00a0", " "))
+
+	txt = strings.ReplaceAll(txt, "\u00a0", " ")
+	txt = reNoiseSpaces.ReplaceAllString(txt, " ")
+
+	if !pageLikelyHasEmailHint(txt) {
+		return false
+	}
+	s.harvestText(txt)
+
+	// 0) gruppierte Autoren-Adressen ({a, b}@uni.de, a|b@lab.org)
+	for _, m := range expandGroupedEmails(txt) {
+		if s.consider(m, pdfViaGrouped) {
+			return true
+		}
+	}
+	// 1) einfache E-Mails
+	for _, m := range reEmailNormal.FindAllString(txt, -1) {
+		if s.consider(m, pdfViaText) {
+			return true
+		}
+		if s.exhausted() {
+			break
+		}
+	}
+	// 2) fragmentierte
+	for _, m := range reEmailFragmented.FindAllStringSubmatch(txt, -1) {
+		if len(m) >= 3 {
+			if s.consider(m[1]+"@"+m[2], pdfViaFragmented) {
+				return true
 			}
 		}
+		if s.exhausted() {
+			break
+		}
 	}
+	// 2b) über Zeilenumbruch/Silbentrennung getrennte (Herkunft „repaired:…“)
+	for _, m := range repaired {
+		if s.consider(m.Email, m.Via) {
+			return true
+		}
+	}
+	// 3) symbolische
+	for _, m := range extractSymbolicEmailsFromText(txt) {
+		if s.consider(m, pdfViaSymbolic) {
+			return true
+		}
+		if s.exhausted() {
+			break
+		}
+	}
+	return false
+}
 
-	if bestEmail == "" {
-		return "", 0, nil
+// result: bester Kandidat und alle Kandidaten absteigend nach Score.
+func (s *docScorer) result() pdfScanResult {
+	harvest := docHarvest{
+		Addresses: s.found,
+		Authors:   s.meta.Authors,
+		Block:     s.authors,
+		Complete:  !s.early && !s.truncated && !s.exhausted(),
 	}
-	return bestEmail, bestScore, nil
+	if s.bestEmail == "" {
+		return pdfScanResult{Harvest: harvest}
+	}
+	sort.SliceStable(s.cands, func(i, j int) bool { return s.cands[i].Score > s.cands[j].Score })
+	return pdfScanResult{Email: s.bestEmail, Score: s.bestScore, Via: s.bestVia, Candidates: s.cands, Harvest: harvest}
 }
 
 // Alte Signatur für evtl. Altaufrufer (ruft ctx-Variante)
@@ -320,34 +548,9 @@ func pageLikelyHasEmailHint(s string) bool {
 	return false
 }
 
-// symbolische E-Mails zusammensetzen
+// symbolische E-Mails zusammensetzen (Grammatik siehe symbolic.go)
 func extractSymbolicEmailsFromText(text string) []string {
-	matches := reEmailSymbolic.FindAllStringSubmatch(text, -1)
-	if len(matches) == 0 {
-		return nil
-	}
-	out := make([]string, 0, len(matches))
-	for _, m := range matches {
-		local := strings.ToLower(strings.TrimSpace(m[1]))
-		if !reLocalOK.MatchString(local) {
-			continue
-		}
-		d := strings.ToLower(m[2])
-		// "dot"-Schreibweisen normalisieren
-		for _, r := range []string{" (dot) ", " dot ", "[dot]", "(dot)", " DOT ", " Dot ", " dot", "dot "} {
-			d = strings.ReplaceAll(d, r, ".")
-		}
-		d = strings.ReplaceAll(d, " ", "")
-		d = strings.ReplaceAll(d, "[.]", ".")
-		d = strings.ReplaceAll(d, "(.)", ".")
-		d = regexp.MustCompile(`\.{2,}`).ReplaceAllString(d, ".")
-		d = strings.Trim(d, ".")
-		if !validDomain(d) {
-			continue
-		}
-		out = append(out, local+"@"+d)
-	}
-	return out
+	return extractObfuscatedEmails(text)
 }
 
 func minInt(a, b int) int {
@@ -0,0 +1,388 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"sync"
+	"time"
+
+	"Bachelorprojekt/internal/pdfsandbox"
+)
+
+// =================== PDF-Worker-Pool ===================
+//
+// Statt je PDF das ganze Binary mit --scanpdf zu starten, laufen wenige
+// langlebige Worker-Prozesse (--pdfworker). Protokoll über stdin/stdout:
+// je Nachricht 4 Byte Länge (big endian) + JSON. Worker liefern alle
+// bewerteten Kandidaten. Nach N Jobs oder über dem Speicherlimit wird ein
+// Worker ersetzt; bei Timeout wird er sofort getötet. Harte Limits und
+// Sandbox: pdfsandbox.go.
+
+const (
+	pdfWorkerFlag     = "--pdfworker"
+	pdfJobTimeout     = 12 * time.Second
+	maxPDFFrameBytes  = 16 << 20
+	pdfWorkerStopWait = 2 * time.Second
+	pdfWorkerStderrKB = 4 // so viel stderr wird für die Fehlerart behalten
+)
+
+var (
+	errPDFWorkerTimeout = errors.New("pdf-worker: timeout")
+	errPDFWorkerCrashed = errors.New("pdf-worker: abgestürzt")
+)
+
+// pdfJobRequest: Auftrag an einen Worker.
+type pdfJobRequest struct {
+	ID          int64  `json:"id"`
+	Path        string `json:"path"`
+	ContentType string `json:"content_type,omitempty"` // Hinweis für die Typerkennung (docextract.go)
+	Person      string `json:"person"`
+	TimeoutMS   int64  `json:"timeout_ms"`
+	CPUSec      int    `json:"cpu_s,omitempty"` // CPU-Budget des Auftrags (0 = -pdf-worker-cpu)
+}
+
+// pdfJobResponse: Antwort mit allen Kandidaten (absteigend nach Score).
+type pdfJobResponse struct {
+	ID         int64          `json:"id"`
+	Type       string         `json:"type"` // erkannter Dokumenttyp
+	Candidates []pdfCandidate `json:"candidates"`
+	Harvest    docHarvest     `json:"harvest"` // alle Adressen/Autoren für den Dokument-Store
+	Error      string         `json:"error,omitempty"`
+	MemMB      int            `json:"mem_mb"` // vom Worker belegter Speicher nach dem Job
+}
+
+// -------------------- Framing --------------------
+
+func writeFrame(w io.Writer, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var hdr [4]byte
+	binary.BigEndian.PutUint32(hdr[:], uint32(len(b)))
+	if _, err := w.Write(hdr[:]); err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+func readFrame(r io.Reader, v any) error {
+	var hdr [4]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return err
+	}
+	n := binary.BigEndian.Uint32(hdr[:])
+	if n > maxPDFFrameBytes {
+		return fmt.Errorf("frame zu groß (%d Bytes)", n)
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// -------------------- Worker-Seite --------------------
+
+// runPDFWorker bearbeitet Aufträge, bis stdin geschlossen wird.
+func runPDFWorker() {
+	// stdout gehört dem Protokoll; verirrte Ausgaben (PDF-Lib) landen auf stderr
+	proto := bufio.NewWriter(os.Stdout)
+	os.Stdout = os.Stderr
+	in := bufio.NewReader(os.Stdin)
+	limits := pdfsandbox.Setup()
+
+	for {
+		var req pdfJobRequest
+		if err := readFrame(in, &req); err != nil {
+			return // EOF: Pool fährt herunter
+		}
+		timeout := time.Duration(req.TimeoutMS) * time.Millisecond
+		if timeout <= 0 {
+			timeout = pdfJobTimeout
+		}
+		jobLimits := limits
+		if req.CPUSec > 0 && jobLimits.CPUPerJobSec > 0 {
+			jobLimits.CPUPerJobSec = req.CPUSec
+		}
+		pdfsandbox.ArmJob(jobLimits)
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		typ, res, err := scanDocumentCandidates(ctx, req.Path, req.ContentType, req.Person)
+		cancel()
+
+		resp := pdfJobResponse{ID: req.ID, Type: typ, Candidates: res.Candidates, Harvest: res.Harvest, MemMB: workerMemMB()}
+		if err != nil {
+			resp.Error = err.Error()
+		}
+		if writeFrame(proto, resp) != nil || proto.Flush() != nil {
+			return
+		}
+	}
+}
+
+// workerMemMB: vom Go-Runtime beim OS angeforderter Speicher (Näherung für RSS).
+func workerMemMB() int {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return int(m.Sys >> 20)
+}
+
+// -------------------- Pool-Seite --------------------
+
+type pdfWorker struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	out    *bufio.Reader
+	stderr *tailBuffer
+	tmpDir string        // privates Temp-Verzeichnis, wird mit dem Worker gelöscht
+	done   chan struct{} // geschlossen, sobald der Prozess beendet ist
+	jobs   int
+}
+
+type pdfWorkerPool struct {
+	mu     sync.Mutex
+	slots  chan struct{}
+	idle   []*pdfWorker
+	nextID int64
+	stats  struct{ started, recycled, killed, limits int }
+}
+
+var pdfPool struct {
+	once sync.Once
+	pool *pdfWorkerPool
+}
+
+// pdfWorkers liefert den gemeinsamen Pool (Worker starten bei Bedarf).
+func pdfWorkers() *pdfWorkerPool {
+	pdfPool.once.Do(func() {
+		pdfPool.pool = &pdfWorkerPool{slots: make(chan struct{}, maxInt(1, runCfg.PDFWorkers))}
+	})
+	return pdfPool.pool
+}
+
+// closePDFWorkers beendet alle Worker am Ende des Laufs.
+func closePDFWorkers() {
+	p := pdfPool.pool
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	idle := p.idle
+	p.idle = nil
+	p.mu.Unlock()
+	for _, w := range idle {
+		w.stop()
+	}
+	p.mu.Lock()
+	stats := p.stats
+	p.mu.Unlock()
+	if stats.started > 0 {
+		fmt.Printf("🧹 PDF-Worker: %d gestartet, %d recycelt, %d getötet, %d Limit-Verstöße\n",
+			stats.started, stats.recycled, stats.killed, stats.limits)
+	}
+}
+
+// scan schickt ein Dokument an einen freien Worker; liefert den erkannten Typ, die
+// Kandidaten absteigend nach Score und den Harvest für den Dokument-Store.
+func (p *pdfWorkerPool) scan(ctx context.Context, path, contentType, person string) (string, pdfScanResult, error) {
+	return p.scanWithCPU(ctx, path, contentType, person, 0)
+}
+
+// scanWithCPU wie scan, mit eigenem CPU-Budget für den Auftrag (0 = -pdf-worker-cpu), etwa für
+// die Korpus-Indizierung, die alle Seiten liest. Der Worker bekommt die Frist abzüglich
+// pdfWorkerStopWait, damit er bei Fristende den bis dahin gelesenen Harvest noch zurückgibt,
+// bevor der Pool ihn tötet.
+func (p *pdfWorkerPool) scanWithCPU(ctx context.Context, path, contentType, person string, cpu time.Duration) (string, pdfScanResult, error) {
+	p.slots <- struct{}{}
+	defer func() { <-p.slots }()
+
+	w, err := p.acquire()
+	if err != nil {
+		return "", pdfScanResult{}, err
+	}
+	p.mu.Lock()
+	p.nextID++
+	id := p.nextID
+	p.mu.Unlock()
+
+	timeout := pdfJobTimeout
+	if dl, ok := ctx.Deadline(); ok {
+		timeout = time.Until(dl)
+		if timeout > 2*pdfWorkerStopWait {
+			timeout -= pdfWorkerStopWait
+		}
+	}
+	cpuSec := int((cpu + time.Second - 1) / time.Second)
+	type result struct {
+		resp pdfJobResponse
+		err  error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		var r result
+		if r.err = writeFrame(w.stdin, pdfJobRequest{ID: id, Path: path, ContentType: contentType, Person: person, TimeoutMS: timeout.Milliseconds(), CPUSec: cpuSec}); r.err == nil {
+			r.err = readFrame(w.out, &r.resp)
+		}
+		ch <- r
+	}()
+
+	select {
+	case <-ctx.Done():
+		p.discard(w, true)
+		<-ch // Leser endet mit dem Prozess
+		return "", pdfScanResult{}, errPDFWorkerTimeout
+	case r := <-ch:
+		if r.err != nil || r.resp.ID != id {
+			return "", pdfScanResult{}, p.failed(w)
+		}
+		w.jobs++
+		// eigenes CPU-Budget zehrt am harten Lebensdauer-Limit (CPUHardSec) → danach ersetzen
+		if w.jobs >= runCfg.PDFWorkerMaxJobs || r.resp.MemMB > runCfg.PDFWorkerMemMB || cpuSec > 0 {
+			p.discard(w, false)
+		} else {
+			p.mu.Lock()
+			p.idle = append(p.idle, w)
+			p.mu.Unlock()
+		}
+		res := pdfScanResult{Candidates: r.resp.Candidates, Harvest: r.resp.Harvest}
+		if r.resp.Error != "" {
+			if kind := classifyWorkerMessage(r.resp.Error, runCfg.PDFWorkerSeccomp); kind != nil {
+				p.countLimit()
+				return r.resp.Type, res, fmt.Errorf("%w: %s", kind, r.resp.Error)
+			}
+			return r.resp.Type, res, errors.New(r.resp.Error)
+		}
+		return r.resp.Type, res, nil
+	}
+}
+
+func (p *pdfWorkerPool) acquire() (*pdfWorker, error) {
+	p.mu.Lock()
+	for len(p.idle) > 0 {
+		w := p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+		select {
+		case <-w.done: // zwischenzeitlich gestorben
+			continue
+		default:
+			p.mu.Unlock()
+			return w, nil
+		}
+	}
+	p.mu.Unlock()
+
+	w, err := startPDFWorker()
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	p.stats.started++
+	p.mu.Unlock()
+	return w, nil
+}
+
+// failed: Worker hat die Verbindung verloren (Absturz oder Limit) → Ursache bestimmen.
+func (p *pdfWorkerPool) failed(w *pdfWorker) error {
+	select {
+	case <-w.done:
+	case <-time.After(pdfWorkerStopWait):
+	}
+	p.discard(w, true)
+	err := classifyWorkerExit(w.cmd.ProcessState, w.stderr, workerLimitsFromConfig().CPUHardSec())
+	if !errors.Is(err, errPDFWorkerCrashed) {
+		p.countLimit()
+	}
+	return err
+}
+
+func (p *pdfWorkerPool) countLimit() {
+	p.mu.Lock()
+	p.stats.limits++
+	p.mu.Unlock()
+}
+
+// discard beendet einen Worker: kill bei Timeout/Fehler, sonst sauber (Recycling).
+func (p *pdfWorkerPool) discard(w *pdfWorker, kill bool) {
+	p.mu.Lock()
+	if kill {
+		p.stats.killed++
+	} else {
+		p.stats.recycled++
+	}
+	p.mu.Unlock()
+	if kill {
+		w.kill()
+		return
+	}
+	w.stop()
+}
+
+func startPDFWorker() (*pdfWorker, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	tmpDir, err := os.MkdirTemp("", "pdfworker-*")
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(exe, pdfWorkerFlag)
+	cmd.Dir = tmpDir
+	// Heap-Ziel (Go-Runtime liest GOMEMLIMIT), harte Limits, privates Temp-Verzeichnis
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("GOMEMLIMIT=%dMiB", runCfg.PDFWorkerMemMB),
+		workerLimitsFromConfig().Env(),
+		"TMPDIR="+tmpDir,
+		"GOTRACEBACK=none", // bei fatal error nur die Meldung (Fehlerart), keine Stacks
+	)
+	stderr := &tailBuffer{max: pdfWorkerStderrKB << 10} // PDF-Lib-Noise; nur das Ende zählt
+	cmd.Stderr = stderr
+	pdfsandbox.ConfigureCmd(cmd)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		os.RemoveAll(tmpDir)
+		return nil, err
+	}
+	w := &pdfWorker{cmd: cmd, stdin: stdin, out: bufio.NewReader(stdout), stderr: stderr, tmpDir: tmpDir, done: make(chan struct{})}
+	go func() {
+		_ = cmd.Wait()
+		os.RemoveAll(w.tmpDir)
+		close(w.done)
+	}()
+	return w, nil
+}
+
+// stop: stdin schließen (Worker endet nach dem Job), notfalls töten.
+func (w *pdfWorker) stop() {
+	_ = w.stdin.Close()
+	select {
+	case <-w.done:
+	case <-time.After(pdfWorkerStopWait):
+		w.kill()
+	}
+}
+
+func (w *pdfWorker) kill() {
+	_ = w.stdin.Close()
+	_ = w.cmd.Process.Kill()
+	<-w.done
+}
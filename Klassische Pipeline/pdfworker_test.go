@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Framing (4 Byte Länge + JSON) über eine Pipe wie zwischen Pool und Worker.
+func TestFrameRoundTrip(t *testing.T) {
+	pr, pw := io.Pipe()
+	want := pdfJobResponse{ID: 7, Type: docTypePDF, Candidates: []pdfCandidate{{Email: "jane.doe@uni-x.de", Score: 12, Via: pdfViaText}}}
+	go func() {
+		pw.CloseWithError(writeFrame(pw, want))
+	}()
+	var got pdfJobResponse
+	if err := readFrame(pr, &got); err != nil {
+		t.Fatalf("readFrame: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readFrame = %+v, erwartet %+v", got, want)
+	}
+}
+
+func TestReadFrameErrors(t *testing.T) {
+	header := func(n uint32) []byte {
+		var hdr [4]byte
+		binary.BigEndian.PutUint32(hdr[:], n)
+		return hdr[:]
+	}
+	tests := []struct {
+		name  string
+		input []byte
+	}{
+		{"leer", nil},
+		{"Kopf abgeschnitten", []byte{0, 0}},
+		{"zu groß", header(maxPDFFrameBytes + 1)},
+		{"Nutzlast abgeschnitten", append(header(100), []byte(`{"id":1`)...)},
+		{"kein JSON", append(header(3), []byte("abc")...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v pdfJobResponse
+			if err := readFrame(bytes.NewReader(tt.input), &v); err == nil {
+				t.Errorf("readFrame(%q): kein Fehler", tt.input)
+			}
+		})
+	}
+}
+
+// fakeWorker: ein Worker, dessen Protokoll über Pipes läuft. reply bekommt den
+// Auftrag und liefert die Antwort (nil = nie antworten). Als Prozess dient das
+// Test-Binary ohne Tests (endet sofort), damit stop/kill echte Prozesse sehen.
+func fakeWorker(t *testing.T, reply func(pdfJobRequest) *pdfJobResponse) *pdfWorker {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Hilfsprozess: %v", err)
+	}
+	reqR, reqW := io.Pipe()
+	respR, respW := io.Pipe()
+	w := &pdfWorker{cmd: cmd, stdin: reqW, out: bufio.NewReader(respR), stderr: &tailBuffer{max: 1 << 10}, done: make(chan struct{})}
+	go func() {
+		_ = cmd.Wait()
+		close(w.done)
+	}()
+	go func() {
+		defer respW.Close()
+		for {
+			var req pdfJobRequest
+			if readFrame(reqR, &req) != nil {
+				return
+			}
+			resp := reply(req)
+			if resp == nil {
+				_, _ = io.Copy(io.Discard, reqR) // hängt bis kill/stop
+				return
+			}
+			if writeFrame(respW, resp) != nil {
+				return
+			}
+		}
+	}()
+	return w
+}
+
+func testPool(w *pdfWorker) *pdfWorkerPool {
+	return &pdfWorkerPool{slots: make(chan struct{}, 1), idle: []*pdfWorker{w}}
+}
+
+func TestPDFWorkerPoolPaths(t *testing.T) {
+	defer func(jobs, mem int) { runCfg.PDFWorkerMaxJobs, runCfg.PDFWorkerMemMB = jobs, mem }(runCfg.PDFWorkerMaxJobs, runCfg.PDFWorkerMemMB)
+	runCfg.PDFWorkerMemMB = 200
+
+	t.Run("Recycling nach MaxJobs", func(t *testing.T) {
+		runCfg.PDFWorkerMaxJobs = 1
+		p := testPool(fakeWorker(t, func(req pdfJobRequest) *pdfJobResponse {
+			return &pdfJobResponse{ID: req.ID, Type: docTypePDF, Candidates: []pdfCandidate{{Email: "jane.doe@uni-x.de", Score: 12}}}
+		}))
+		typ, res, err := p.scan(context.Background(), "x.pdf", "", "Jane Doe")
+		if err != nil || typ != docTypePDF || len(res.Candidates) != 1 {
+			t.Fatalf("scan = %q, %+v, %v", typ, res, err)
+		}
+		if p.stats.recycled != 1 || p.stats.killed != 0 || len(p.idle) != 0 {
+			t.Errorf("stats = %+v, idle = %d; erwartet 1 recycelt, Worker nicht mehr frei", p.stats, len(p.idle))
+		}
+	})
+
+	t.Run("Worker bleibt frei", func(t *testing.T) {
+		runCfg.PDFWorkerMaxJobs = 50
+		p := testPool(fakeWorker(t, func(req pdfJobRequest) *pdfJobResponse {
+			return &pdfJobResponse{ID: req.ID, Type: docTypePDF}
+		}))
+		if _, _, err := p.scan(context.Background(), "x.pdf", "", "Jane Doe"); err != nil {
+			t.Fatalf("scan: %v", err)
+		}
+		if len(p.idle) != 1 || p.stats.recycled != 0 {
+			t.Errorf("stats = %+v, idle = %d; erwartet Worker wieder frei", p.stats, len(p.idle))
+		}
+		p.idle[0].kill()
+	})
+
+	t.Run("falsche ID", func(t *testing.T) {
+		p := testPool(fakeWorker(t, func(req pdfJobRequest) *pdfJobResponse {
+			return &pdfJobResponse{ID: req.ID + 1}
+		}))
+		if _, _, err := p.scan(context.Background(), "x.pdf", "", "Jane Doe"); err == nil {
+			t.Fatal("scan: kein Fehler bei falscher Antwort-ID")
+		}
+		if p.stats.killed != 1 || len(p.idle) != 0 {
+			t.Errorf("stats = %+v, idle = %d; erwartet getöteten Worker", p.stats, len(p.idle))
+		}
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		p := testPool(fakeWorker(t, func(pdfJobRequest) *pdfJobResponse { return nil }))
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		if _, _, err := p.scan(ctx, "x.pdf", "", "Jane Doe"); !errors.Is(err, errPDFWorkerTimeout) {
+			t.Fatalf("scan: %v, erwartet %v", err, errPDFWorkerTimeout)
+		}
+		if p.stats.killed != 1 || len(p.idle) != 0 {
+			t.Errorf("stats = %+v, idle = %d; erwartet getöteten Worker", p.stats, len(p.idle))
+		}
+	})
+}
+
+func TestClassifyWorkerMessage(t *testing.T) {
+	tests := []struct {
+		msg     string
+		seccomp bool
+		want    error
+	}{
+		{"open /tmp/x.pdf: operation not permitted", false, nil},
+		{"open /tmp/x.pdf: operation not permitted", true, errPDFWorkerSandbox},
+		{"bad system call", true, errPDFWorkerSandbox},
+		{"runtime: out of memory", false, errPDFWorkerMemLimit},
+		{"open x: too many open files", false, errPDFWorkerFileLimit},
+		{"malformed PDF", true, nil},
+	}
+	for _, tt := range tests {
+		if got := classifyWorkerMessage(tt.msg, tt.seccomp); got != tt.want {
+			t.Errorf("classifyWorkerMessage(%q, %v) = %v, erwartet %v", tt.msg, tt.seccomp, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/temoto/robotstxt"
+)
+
+// =================== robots.txt-Policy ===================
+//
+// Gemeinsame Schicht für Colly, Chromedp, PDF- und vCard-Downloads:
+// robots.txt wird je Host einmal geladen und gecacht; Disallow und
+// Crawl-delay werden für unseren User-Agent beachtet. Abschaltbar mit
+// --robots=false. Die DDG-Suche selbst ist ausgenommen.
+
+const (
+	robotsAgent        = "BachelorprojektEmailBot" // Gruppe in robots.txt (sonst greift „*“)
+	robotsFetchTimeout = 6 * time.Second
+	maxRobotsBytes     = 512 << 10
+	maxCrawlDelay      = 10 * time.Second // höhere Werte werden gekappt
+)
+
+type robotsEntry struct {
+	data *robotstxt.RobotsData // nil → alles erlaubt (Fehler/fehlend)
+}
+
+var robots = struct {
+	sync.Mutex
+	hosts     map[string]*robotsEntry
+	lastVisit map[string]time.Time
+}{
+	hosts:     map[string]*robotsEntry{},
+	lastVisit: map[string]time.Time{},
+}
+
+// robotsAllowed prüft eine URL gegen robots.txt. Bei Sperre kommt ein Grund zurück.
+func robotsAllowed(rawURL string) (bool, string) {
+	if !runCfg.RespectRobots {
+		return true, ""
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return true, ""
+	}
+	group := robotsGroup(u)
+	if group == nil {
+		return true, ""
+	}
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	if !group.Test(path) {
+		return false, fmt.Sprintf("robots.txt von %s sperrt %s", u.Host, path)
+	}
+	return true, ""
+}
+
+// robotsWait blockiert, bis der Crawl-delay des Hosts seit dem letzten Abruf verstrichen ist.
+func robotsWait(rawURL string) {
+	if !runCfg.RespectRobots || runCfg.Offline {
+		return
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return
+	}
+	group := robotsGroup(u)
+	if group == nil || group.CrawlDelay <= 0 {
+		return
+	}
+	delay := group.CrawlDelay
+	if delay > maxCrawlDelay {
+		delay = maxCrawlDelay
+	}
+	host := strings.ToLower(u.Host)
+	robots.Lock()
+	wait := time.Until(robots.lastVisit[host].Add(delay))
+	robots.lastVisit[host] = time.Now().Add(maxDuration(wait, 0))
+	robots.Unlock()
+	if wait > 0 {
+		fmt.Printf("🐢 Crawl-delay %s: %.1fs\n", host, wait.Seconds())
+		time.Sleep(wait)
+	}
+}
+
+// robotsCheck = robotsAllowed + robotsWait; loggt gesperrte URLs mit Grund.
+func robotsCheck(rawURL, fetcher string) error {
+	if ok, reason := robotsAllowed(rawURL); !ok {
+		fmt.Printf("🚫 [%s] übersprungen: %s\n", fetcher, reason)
+		return fmt.Errorf("robots: %s", reason)
+	}
+	robotsWait(rawURL)
+	return nil
+}
+
+func robotsGroup(u *url.URL) *robotstxt.Group {
+	key := strings.ToLower(u.Scheme + "://" + u.Host)
+	robots.Lock()
+	entry, ok := robots.hosts[key]
+	robots.Unlock()
+	if !ok {
+		entry = &robotsEntry{data: fetchRobots(key + "/robots.txt")}
+		robots.Lock()
+		robots.hosts[key] = entry
+		robots.Unlock()
+	}
+	if entry.data == nil {
+		return nil
+	}
+	return entry.data.FindGroup(robotsAgent)
+}
+
+func fetchRobots(robotsURL string) *robotstxt.RobotsData {
+	ctx, cancel := context.WithTimeout(context.Background(), robotsFetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", robotsURL, nil)
+	if err != nil {
+		return nil
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64)")
+	resp, err := cachedClient(cacheKindAux, robotsFetchTimeout).Do(req)
+	if err != nil {
+		return nil // nicht erreichbar → nicht blockieren
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRobotsBytes))
+	if err != nil {
+		return nil
+	}
+	data, err := robotstxt.FromStatusAndBytes(resp.StatusCode, body)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func maxDuration(a, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -21,7 +21,7 @@ type ddgOptions struct {
 	ReqTimeout    time.Duration // Timeout pro HTTP-Request
 	VerifyLinks   bool          // HEAD-Check der gefundenen URLs
 	Workers       int           // parallele Verifizierungs-Worker
-	PDFOnly       bool          // nur .pdf-Links zurückgeben
+	DocOnly       bool          // nur Dokument-Links (.pdf, .docx … je nach -doc-types)
 	MinDelay      time.Duration // min. Delay zwischen Seitenabfragen
 	MaxDelay      time.Duration // max. Delay zwischen Seitenabfragen
 	UserAgent     string        // User-Agent für Requests
@@ -36,7 +36,7 @@ func defaultDDGOptions() ddgOptions {
 		ReqTimeout:    10 * time.Second,
 		VerifyLinks:   true,
 		Workers:       6,
-		PDFOnly:       false,
+		DocOnly:       false,
 		MinDelay:      300 * time.Millisecond,
 		MaxDelay:      1200 * time.Millisecond,
 		UserAgent:     "Mozilla/5.0 (Windows NT 10.0; Win64; x64)",
@@ -50,14 +50,14 @@ func defaultDDGOptions() ddgOptions {
 // Wie bisher: allgemeine Websuche → URLs
 func DuckDuckGoSearch(query string) ([]string, error) {
 	opts := defaultDDGOptions()
-	opts.PDFOnly = false
+	opts.DocOnly = false
 	return duckDuckGoSearch(query, opts)
 }
 
 // DuckDuckGoPDFSearch baut "höfliche" Defaults und ruft deine bestehende duckDuckGoSearch(query, opts)
 func DuckDuckGoPDFSearch(query string) ([]string, error) {
 	opts := defaultDDGOptions()
-	opts.PDFOnly = true
+	opts.DocOnly = true
 	opts.Limit = 6 // passend zu main.maxLinksPDF
 	opts.MaxPages = 2
 	opts.VerifyLinks = false // keine parallelen HEAD-Checks
@@ -79,10 +79,16 @@ func duckDuckGoSearch(query string, opts ddgOptions) ([]string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
 	defer cancel()
 
-	client := &http.Client{Timeout: opts.ReqTimeout}
+	client := cachedClient(cacheKindSearch, opts.ReqTimeout)
+	if runCfg.Offline {
+		// HEAD-Checks bräuchten das Netz; Treffer kommen ohnehin aus dem Cache
+		opts.VerifyLinks = false
+		opts.MinDelay, opts.MaxDelay = 0, 0
+	}
 	var (
 		results   = make([]string, 0, opts.Limit)
 		seen      = make(map[string]struct{}, opts.Limit*2)
+		sniffURLs []string // Dokument-Kandidaten ohne Endung, geprüft nach den Suchseiten
 		pageIndex = 0
 	)
 
@@ -105,6 +111,11 @@ func duckDuckGoSearch(query string, opts ddgOptions) ([]string, error) {
 
 		resp, err := client.Do(req)
 		if err != nil {
+			if len(results) > 0 || len(sniffURLs) > 0 {
+				// Budget erschöpft o. Ä.: bisherige Treffer nicht verwerfen
+				fmt.Printf("⚠️ DDG Seite %d: %v (behalte bisherige Treffer)\n", pageIndex+1, err)
+				break
+			}
 			return nil, fmt.Errorf("ddg request failed: %w", err)
 		}
 		doc, err := goquery.NewDocumentFromReader(resp.Body)
@@ -125,14 +136,19 @@ func duckDuckGoSearch(query string, opts ddgOptions) ([]string, error) {
 			if err != nil || decoded == "" {
 				return
 			}
-			if opts.PDFOnly && !strings.HasSuffix(strings.ToLower(decoded), ".pdf") {
-				return
-			}
 			// Normieren: Whitespace raus, http→https wenn möglich (keine strikte Umwandlung)
 			u := strings.TrimSpace(decoded)
 			if _, exists := seen[u]; exists {
 				return
 			}
+			if opts.DocOnly && !docTypeEnabled(docTypeByURL(u)) {
+				// Download-Links ohne Endung (arXiv, Repositorien) später per HEAD/Range-GET prüfen
+				if runCfg.SniffDocLinks && len(sniffURLs) < sniffMaxPerSearch && looksLikeDocumentURL(u) {
+					seen[u] = struct{}{}
+					sniffURLs = append(sniffURLs, u)
+				}
+				return
+			}
 			seen[u] = struct{}{}
 			pageURLs = append(pageURLs, u)
 		})
@@ -168,11 +184,17 @@ func duckDuckGoSearch(query string, opts ddgOptions) ([]string, error) {
 		select {
 		case <-time.After(sleep):
 		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
 			break
 		}
 		pageIndex++
 	}
 
+	// Prüfung mit eigenem Budget, damit langsame Hosts die Suche nicht aufbrauchen
+	if room := opts.Limit - len(results); room > 0 && len(sniffURLs) > 0 {
+		results = append(results, sniffDocumentLinks(sniffURLs, room)...)
+	}
 	return results, nil
 }
 
@@ -231,9 +253,9 @@ func headVerifyBatch(ctx context.Context, client *http.Client, urls []string, wo
 						reqGet, err3 := http.NewRequestWithContext(ctx, "GET", j.u, nil)
 						if err3 == nil {
 							reqGet.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64)")
-							// kleine Deadline, um nicht zu hängen
-							getClient := *client
-							getClient.Timeout = 5 * time.Second
+							// kleine Deadline, um nicht zu hängen; ohne Cache (nur Erreichbarkeit,
+							// der Seiteninhalt wird später von Colly unter cacheKindPage geholt)
+							getClient := &http.Client{Timeout: 5 * time.Second}
 							if resp2, err4 := getClient.Do(reqGet); err4 == nil {
 								if resp2.Body != nil {
 									resp2.Body.Close()
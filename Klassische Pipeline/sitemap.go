@@ -0,0 +1,344 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// =================== Sitemap-Discovery ===================
+//
+// Kennen wir die Domain der Institution (Hint-Spalte, frühere Person oder
+// DDG-Treffer), lesen wir robots.txt („Sitemap:“) und sitemap.xml und suchen
+// URLs, deren Pfad den Namen der Person enthält (/people/firstname-lastname).
+// Diese Seiten werden vor den DDG-Treffern besucht.
+
+const (
+	sitemapTimeBudget  = 25 * time.Second // gesamt je Domain
+	sitemapReqTimeout  = 10 * time.Second
+	maxSitemapBytes    = 12 << 20 // je Datei (entpackt)
+	maxSitemapFiles    = 20       // inkl. Index-Unterdateien
+	maxSitemapURLs     = 200_000  // pro Domain im Speicher
+	maxSitemapProfiles = 5        // max. Profil-URLs je Person
+)
+
+// Teil-Sitemaps mit diesen Wörtern zuerst laden.
+var sitemapPeopleHints = []string{"people", "person", "staff", "team", "profile", "mitarbeiter", "personen", "faculty", "member"}
+
+var (
+	institutionDomainsMu sync.Mutex
+	institutionDomains   = map[string]string{} // Org (lower) → registrierbare Domain
+
+	sitemapCacheMu sync.Mutex
+	sitemapCache   = map[string][]string{} // Domain → alle URLs aus den Sitemaps
+)
+
+type sitemapDoc struct {
+	URLs     []sitemapLoc `xml:"url"`
+	Sitemaps []sitemapLoc `xml:"sitemap"`
+}
+
+type sitemapLoc struct {
+	Loc string `xml:"loc"`
+}
+
+// knownInstitutionDomain: Domain aus Hint-Spalte oder von einer früheren Person derselben Institution.
+func knownInstitutionDomain(p PersonEntry) string {
+	if d := registrableDomain(p.Hint); d != "" {
+		return d
+	}
+	institutionDomainsMu.Lock()
+	defer institutionDomainsMu.Unlock()
+	return institutionDomains[strings.ToLower(strings.TrimSpace(p.Institution))]
+}
+
+// learnInstitutionDomain leitet die Domain aus Suchtreffern ab (Brand ≈ Org-Token oder
+// Org-Akronym) und merkt sie sich für weitere Personen der Institution.
+func learnInstitutionDomain(p PersonEntry, links []string) string {
+	org := strings.TrimSpace(p.Institution)
+	if org == "" {
+		return ""
+	}
+	orgTokens := tokenizeOrg(asciiFold(org))
+	for _, l := range links {
+		d := registrableDomain(l)
+		if d == "" {
+			continue
+		}
+		brand := strings.Split(d, ".")[0]
+		if len(brand) < 2 {
+			continue
+		}
+		if brandMatchesOrgAcronym(d, asciiFold(org)) || (len(brand) >= 3 && tokenContainedInBrand(orgTokens, brand)) {
+			institutionDomainsMu.Lock()
+			institutionDomains[strings.ToLower(org)] = d
+			institutionDomainsMu.Unlock()
+			return d
+		}
+	}
+	return ""
+}
+
+// registrableDomain: „https://www.cs.bu.edu/x“ oder „cs.bu.edu“ → „bu.edu“.
+func registrableDomain(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ""
+	}
+	if !strings.Contains(s, "://") {
+		s = "https://" + s
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Hostname() == "" || !strings.Contains(u.Hostname(), ".") {
+		return ""
+	}
+	d, err := publicsuffix.EffectiveTLDPlusOne(strings.ToLower(u.Hostname()))
+	if err != nil {
+		return ""
+	}
+	return d
+}
+
+// discoverSitemapProfiles liefert Profil-URLs der Person aus den Sitemaps der Domain.
+func discoverSitemapProfiles(domain, first, last string) []string {
+	if domain == "" || last == "" {
+		return nil
+	}
+	urls := sitemapURLs(domain)
+	if len(urls) == 0 {
+		return nil
+	}
+	slugs := nameSlugs(first, last)
+
+	type hit struct {
+		u     string
+		score int
+	}
+	var hits []hit
+	for _, u := range urls {
+		pu, err := url.Parse(u)
+		if err != nil {
+			continue
+		}
+		path := strings.ToLower(pu.Path)
+		if sc := slugScore(path, slugs, last); sc > 0 {
+			hits = append(hits, hit{u: u, score: sc})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		return len(hits[i].u) < len(hits[j].u) // kürzere Pfade sind eher die Profilseite
+	})
+	out := make([]string, 0, maxSitemapProfiles)
+	for _, h := range hits {
+		if len(out) >= maxSitemapProfiles {
+			break
+		}
+		out = append(out, h.u)
+	}
+	return out
+}
+
+// nameSlugs: firstname-lastname, lastname-firstname, firstname.lastname, … (ASCII + de-Umschrift).
+func nameSlugs(first, last string) []string {
+	var out []string
+	seen := map[string]struct{}{}
+	for _, f := range nameSpellings(first) {
+		for _, l := range nameSpellings(last) {
+			for _, sep := range []string{"-", ".", "_", ""} {
+				for _, s := range []string{f + sep + l, l + sep + f} {
+					if f == "" {
+						s = l
+					}
+					if _, ok := seen[s]; !ok {
+						seen[s] = struct{}{}
+						out = append(out, s)
+					}
+				}
+			}
+		}
+	}
+	return out
+}
+
+// nameSpellings: „allgöwer“ → allgower, allgoewer.
+func nameSpellings(s string) []string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return []string{""}
+	}
+	folded := asciiFold(s)
+	de := asciiFold(strings.NewReplacer("ä", "ae", "ö", "oe", "ü", "ue", "ß", "ss").Replace(s))
+	if de == folded {
+		return []string{folded}
+	}
+	return []string{folded, de}
+}
+
+// slugScore: voller Namens-Slug im Pfad > „~nachname“ > Nachname als eigenes Segment.
+func slugScore(path string, slugs []string, last string) int {
+	for _, s := range slugs {
+		if len(s) > len(last)+1 && strings.Contains(path, s) {
+			return 3
+		}
+	}
+	for _, l := range nameSpellings(last) {
+		if len(l) < 4 {
+			continue
+		}
+		if strings.Contains(path, "~"+l) {
+			return 2
+		}
+		for _, seg := range strings.Split(path, "/") {
+			seg = strings.TrimSuffix(strings.TrimSuffix(seg, ".html"), ".htm")
+			if seg == l {
+				return 1
+			}
+		}
+	}
+	return 0
+}
+
+// sitemapURLs lädt (einmal je Domain) alle Sitemap-URLs: robots.txt-Einträge + Standardorte.
+func sitemapURLs(domain string) []string {
+	sitemapCacheMu.Lock()
+	if urls, ok := sitemapCache[domain]; ok {
+		sitemapCacheMu.Unlock()
+		return urls
+	}
+	sitemapCacheMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), sitemapTimeBudget)
+	defer cancel()
+	client := cachedClient(cacheKindAux, sitemapReqTimeout)
+
+	queue := []string{}
+	for _, host := range []string{domain, "www." + domain} {
+		queue = append(queue, robotsSitemaps(ctx, client, "https://"+host+"/robots.txt")...)
+	}
+	queue = append(queue, "https://"+domain+"/sitemap.xml", "https://www."+domain+"/sitemap.xml")
+
+	var urls []string
+	seenFiles := map[string]struct{}{}
+	files := 0
+	for len(queue) > 0 && files < maxSitemapFiles && len(urls) < maxSitemapURLs && ctx.Err() == nil {
+		sm := queue[0]
+		queue = queue[1:]
+		if _, ok := seenFiles[sm]; ok {
+			continue
+		}
+		seenFiles[sm] = struct{}{}
+		doc, err := fetchSitemap(ctx, client, sm)
+		if err != nil {
+			continue
+		}
+		files++
+		for _, u := range doc.URLs {
+			if loc := strings.TrimSpace(u.Loc); loc != "" && len(urls) < maxSitemapURLs {
+				urls = append(urls, loc)
+			}
+		}
+		// Index: personenbezogene Teil-Sitemaps nach vorne
+		var people, rest []string
+		for _, s := range doc.Sitemaps {
+			loc := strings.TrimSpace(s.Loc)
+			if loc == "" {
+				continue
+			}
+			if containsAny(strings.ToLower(loc), sitemapPeopleHints) {
+				people = append(people, loc)
+			} else {
+				rest = append(rest, loc)
+			}
+		}
+		queue = append(append(people, queue...), rest...)
+	}
+
+	fmt.Printf("🗺️ Sitemap %s: %d URLs aus %d Dateien\n", domain, len(urls), files)
+	sitemapCacheMu.Lock()
+	sitemapCache[domain] = urls
+	sitemapCacheMu.Unlock()
+	return urls
+}
+
+// robotsSitemaps liest die „Sitemap:“-Zeilen aus robots.txt.
+func robotsSitemaps(ctx context.Context, client *http.Client, robotsURL string) []string {
+	body, err := fetchLimited(ctx, client, robotsURL, 512<<10)
+	if err != nil {
+		return nil
+	}
+	var out []string
+	sc := bufio.NewScanner(bytes.NewReader(body))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if len(line) > 8 && strings.EqualFold(line[:8], "sitemap:") {
+			if u := strings.TrimSpace(line[8:]); u != "" {
+				out = append(out, u)
+			}
+		}
+	}
+	return out
+}
+
+func fetchSitemap(ctx context.Context, client *http.Client, u string) (*sitemapDoc, error) {
+	body, err := fetchLimited(ctx, client, u, maxSitemapBytes)
+	if err != nil {
+		return nil, err
+	}
+	// .xml.gz (auch ohne passenden Content-Type)
+	if len(body) > 2 && body[0] == 0x1f && body[1] == 0x8b {
+		zr, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		body, err = io.ReadAll(io.LimitReader(zr, maxSitemapBytes))
+		if err != nil {
+			return nil, err
+		}
+	}
+	var doc sitemapDoc
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func fetchLimited(ctx context.Context, client *http.Client, u string, limit int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64)")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, limit))
+}
+
+func containsAny(s string, words []string) bool {
+	for _, w := range words {
+		if strings.Contains(s, w) {
+			return true
+		}
+	}
+	return false
+}
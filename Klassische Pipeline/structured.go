@@ -0,0 +1,367 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// =================== Strukturierte Personendaten ===================
+//
+// Viele Profilseiten tragen die Adresse maschinenlesbar: JSON-LD
+// („@type“: „Person“), Microdata/RDFa (schema.org, FOAF), hCard-Mikroformate
+// oder eine verlinkte .vcf-Datei. Passt der Name im Datensatz zur gesuchten
+// Person, ist die Adresse ein sehr sicherer Kandidat.
+
+const (
+	structuredMatchBonus = 6               // Bonus, wenn Name im Datensatz passt
+	vcardFetchTimeout    = 8 * time.Second // Download-Timeout je .vcf
+	maxVCardBytes        = 64 << 10        // .vcf-Dateien sind klein
+	maxVCardLinks        = 3               // max. .vcf-Links pro Seite
+)
+
+// structuredPerson ist ein Personen-Datensatz aus strukturierten Daten.
+type structuredPerson struct {
+	Name   string
+	Email  string
+	Origin string // "json-ld", "microdata", "rdfa", "hcard", "vcard"
+}
+
+// extractStructuredPersons sammelt Personen aus allen Formaten der Seite selbst
+// (ohne Netzwerk). Verlinkte vCards: linkedVCards + fetchLinkedVCards.
+func extractStructuredPersons(doc *goquery.Selection) []structuredPerson {
+	var out []structuredPerson
+	out = append(out, extractJSONLDPersons(doc)...)
+	out = append(out, extractMicrodataPersons(doc)...)
+	out = append(out, extractRDFaPersons(doc)...)
+	out = append(out, extractHCardPersons(doc)...)
+	return out
+}
+
+// scoreStructuredPerson bewertet einen Datensatz: normaler Score, bei
+// passendem Namen mindestens hardAcceptScore.
+func scoreStructuredPerson(p structuredPerson, first, middle, last, org string) (string, int) {
+	mail := extractEmailFromText(p.Email)
+	if mail == "" {
+		return "", 0
+	}
+	score := getScoreOrgGeneral(strings.ToLower(mail), first, middle, last, org)
+	if structuredNameMatches(p.Name, first, last) {
+		score = maxInt(score+structuredMatchBonus, hardAcceptScore)
+	}
+	return mail, score
+}
+
+// structuredNameMatches: Nachname muss vorkommen, Vorname oder Initiale ebenfalls.
+func structuredNameMatches(recName, first, last string) bool {
+	if recName == "" || last == "" {
+		return false
+	}
+	toks := strings.FieldsFunc(asciiFold(strings.ToLower(recName)), func(r rune) bool {
+		return r == ' ' || r == ',' || r == '.' || r == ';'
+	})
+	first = asciiFold(strings.ToLower(first))
+	last = asciiFold(strings.ToLower(last))
+	hasLast, hasFirst := false, first == ""
+	for _, t := range toks {
+		if t == last {
+			hasLast = true
+		} else if first != "" && (t == first || (len(t) == 1 && t[0] == first[0])) {
+			hasFirst = true
+		}
+	}
+	return hasLast && hasFirst
+}
+
+// -------------------- JSON-LD --------------------
+
+func extractJSONLDPersons(doc *goquery.Selection) []structuredPerson {
+	var out []structuredPerson
+	doc.Find(`script[type="application/ld+json"]`).Each(func(_ int, s *goquery.Selection) {
+		var v interface{}
+		if err := json.Unmarshal([]byte(strings.TrimSpace(s.Text())), &v); err != nil {
+			return
+		}
+		walkJSONLD(v, &out)
+	})
+	return out
+}
+
+// walkJSONLD durchläuft Objekte, Arrays und @graph rekursiv.
+func walkJSONLD(v interface{}, out *[]structuredPerson) {
+	switch x := v.(type) {
+	case []interface{}:
+		for _, it := range x {
+			walkJSONLD(it, out)
+		}
+	case map[string]interface{}:
+		if jsonLDIsPerson(x["@type"]) {
+			name := jsonLDString(x["name"])
+			if name == "" {
+				name = strings.TrimSpace(jsonLDString(x["givenName"]) + " " + jsonLDString(x["familyName"]))
+			}
+			for _, em := range jsonLDStrings(x["email"]) {
+				*out = append(*out, structuredPerson{Name: name, Email: strings.TrimPrefix(em, "mailto:"), Origin: "json-ld"})
+			}
+		}
+		for k, child := range x {
+			if k == "@type" || k == "email" {
+				continue
+			}
+			walkJSONLD(child, out)
+		}
+	}
+}
+
+func jsonLDIsPerson(t interface{}) bool {
+	for _, s := range jsonLDStrings(t) {
+		s = strings.ToLower(s)
+		if s == "person" || strings.HasSuffix(s, "/person") || s == "schema:person" {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonLDString(v interface{}) string {
+	if ss := jsonLDStrings(v); len(ss) > 0 {
+		return ss[0]
+	}
+	return ""
+}
+
+func jsonLDStrings(v interface{}) []string {
+	switch x := v.(type) {
+	case string:
+		return []string{strings.TrimSpace(x)}
+	case []interface{}:
+		var out []string
+		for _, it := range x {
+			out = append(out, jsonLDStrings(it)...)
+		}
+		return out
+	case map[string]interface{}:
+		// {"@value": "..."} / {"@id": "mailto:..."}
+		if s, ok := x["@value"].(string); ok {
+			return []string{s}
+		}
+		if s, ok := x["@id"].(string); ok {
+			return []string{s}
+		}
+	}
+	return nil
+}
+
+// -------------------- Microdata --------------------
+
+func extractMicrodataPersons(doc *goquery.Selection) []structuredPerson {
+	var out []structuredPerson
+	doc.Find(`[itemscope][itemtype*="Person"], [itemscope][itemtype*="person"]`).Each(func(_ int, s *goquery.Selection) {
+		name := structuredPropValue(s.Find(`[itemprop="name"]`).First())
+		if name == "" {
+			name = strings.TrimSpace(structuredPropValue(s.Find(`[itemprop="givenName"]`).First()) + " " +
+				structuredPropValue(s.Find(`[itemprop="familyName"]`).First()))
+		}
+		s.Find(`[itemprop="email"]`).Each(func(_ int, e *goquery.Selection) {
+			out = append(out, structuredPerson{Name: name, Email: structuredPropValue(e), Origin: "microdata"})
+		})
+	})
+	return out
+}
+
+// -------------------- RDFa --------------------
+
+func extractRDFaPersons(doc *goquery.Selection) []structuredPerson {
+	var out []structuredPerson
+	doc.Find(`[typeof]`).Each(func(_ int, s *goquery.Selection) {
+		t := strings.ToLower(s.AttrOr("typeof", ""))
+		if !strings.Contains(t, "person") {
+			return
+		}
+		name := structuredPropValue(s.Find(`[property="name"], [property="schema:name"], [property="foaf:name"]`).First())
+		s.Find(`[property="email"], [property="schema:email"], [property="foaf:mbox"], [rel="foaf:mbox"]`).Each(func(_ int, e *goquery.Selection) {
+			out = append(out, structuredPerson{Name: name, Email: structuredPropValue(e), Origin: "rdfa"})
+		})
+	})
+	return out
+}
+
+// -------------------- hCard / h-card --------------------
+
+func extractHCardPersons(doc *goquery.Selection) []structuredPerson {
+	var out []structuredPerson
+	doc.Find(`.vcard, .h-card`).Each(func(_ int, s *goquery.Selection) {
+		name := structuredPropValue(s.Find(`.fn, .p-name`).First())
+		s.Find(`.email, .u-email`).Each(func(_ int, e *goquery.Selection) {
+			out = append(out, structuredPerson{Name: name, Email: structuredPropValue(e), Origin: "hcard"})
+		})
+	})
+	return out
+}
+
+// structuredPropValue: content/href/value-Attribut vor sichtbarem Text.
+func structuredPropValue(s *goquery.Selection) string {
+	if s.Length() == 0 {
+		return ""
+	}
+	for _, attr := range []string{"content", "href", "resource", "value"} {
+		if v, ok := s.Attr(attr); ok && strings.TrimSpace(v) != "" {
+			v = strings.TrimSpace(v)
+			if strings.HasPrefix(strings.ToLower(v), "mailto:") {
+				return extractAddressFromMailto(v)
+			}
+			return v
+		}
+	}
+	return strings.Join(strings.Fields(s.Text()), " ")
+}
+
+// -------------------- verlinkte vCards --------------------
+
+// vcardLink ist ein vCard-Link einer Seite.
+type vcardLink struct {
+	URL   string
+	Page  string // Seite mit dem Link (Quelle des Kandidaten)
+	Typed bool   // .vcf bzw. type="text/vcard" – sonst muss der Content-Type passen
+}
+
+// linkedVCards sammelt vCard-Links einer Seite, ohne sie abzurufen (Colly ruft sie
+// erst nach dem Crawl ab, nicht im OnHTML-Callback). Links, die „vcard“ nur im
+// Namen tragen (/people/x/vcard, ?eID=vcard), zählen nur ohne Seiten-Endung und
+// nur mit passendem Content-Type.
+func linkedVCards(doc *goquery.Selection, pageURL string) []vcardLink {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil
+	}
+	var out []vcardLink
+	seen := map[string]struct{}{}
+	doc.Find(`a[href]`).EachWithBreak(func(_ int, a *goquery.Selection) bool {
+		href := strings.TrimSpace(a.AttrOr("href", ""))
+		ref, err := url.Parse(href)
+		if err != nil {
+			return true
+		}
+		ext := strings.ToLower(path.Ext(ref.Path))
+		typed := ext == ".vcf" || isVCardType(a.AttrOr("type", ""))
+		if !typed && (!strings.Contains(strings.ToLower(href), "vcard") || vcardPageExt[ext]) {
+			return true
+		}
+		abs := base.ResolveReference(ref).String()
+		if _, ok := seen[abs]; ok {
+			return true
+		}
+		seen[abs] = struct{}{}
+		out = append(out, vcardLink{URL: abs, Page: pageURL, Typed: typed})
+		return len(seen) < maxVCardLinks
+	})
+	return out
+}
+
+// Endungen, hinter denen keine vCard steht (Info-/Navigationsseiten, Bilder).
+var vcardPageExt = map[string]bool{
+	".html": true, ".htm": true, ".shtml": true, ".xhtml": true, ".pdf": true,
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".svg": true,
+}
+
+func isVCardType(ct string) bool {
+	mt, _, err := mime.ParseMediaType(ct)
+	return err == nil && (mt == "text/vcard" || mt == "text/x-vcard" || mt == "text/directory")
+}
+
+// fetchLinkedVCards ruft höchstens maxVCardLinks vCards ab; Quelle je Person ist die verlinkende Seite.
+func fetchLinkedVCards(links []vcardLink) (persons []structuredPerson, pages []string) {
+	seen := map[string]struct{}{}
+	for _, l := range links {
+		if _, ok := seen[l.URL]; ok || len(seen) >= maxVCardLinks {
+			continue
+		}
+		seen[l.URL] = struct{}{}
+		for _, p := range fetchVCardPersons(l) {
+			persons = append(persons, p)
+			pages = append(pages, l.Page)
+		}
+	}
+	return persons, pages
+}
+
+func fetchVCardPersons(l vcardLink) []structuredPerson {
+	if robotsCheck(l.URL, "vCard") != nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), vcardFetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", l.URL, nil)
+	if err != nil {
+		return nil
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64)")
+	req.Header.Set("Accept", "text/vcard,text/x-vcard,text/directory;q=0.9,*/*;q=0.5")
+	resp, err := cachedClient(cacheKindAux, vcardFetchTimeout).Do(req)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 || (!l.Typed && !isVCardType(resp.Header.Get("Content-Type"))) {
+		return nil
+	}
+	return parseVCard(io.LimitReader(resp.Body, maxVCardBytes))
+}
+
+// parseVCard liest FN/N und EMAIL je BEGIN:VCARD … END:VCARD (inkl. Zeilenfortsetzung).
+func parseVCard(r io.Reader) []structuredPerson {
+	var (
+		out    []structuredPerson
+		lines  []string
+		sc     = bufio.NewScanner(r)
+		name   string
+		emails []string
+	)
+	for sc.Scan() {
+		l := strings.TrimRight(sc.Text(), "\r")
+		if (strings.HasPrefix(l, " ") || strings.HasPrefix(l, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += l[1:]
+			continue
+		}
+		lines = append(lines, l)
+	}
+	for _, l := range lines {
+		i := strings.IndexByte(l, ':')
+		if i < 0 {
+			continue
+		}
+		key := strings.ToUpper(strings.SplitN(l[:i], ";", 2)[0])
+		val := strings.TrimSpace(l[i+1:])
+		switch key {
+		case "BEGIN":
+			name, emails = "", nil
+		case "FN":
+			name = val
+		case "N":
+			if name == "" {
+				// N:Nachname;Vorname;…
+				parts := strings.Split(val, ";")
+				if len(parts) >= 2 {
+					name = strings.TrimSpace(parts[1] + " " + parts[0])
+				}
+			}
+		case "EMAIL":
+			emails = append(emails, strings.TrimPrefix(val, "mailto:"))
+		case "END":
+			for _, em := range emails {
+				out = append(out, structuredPerson{Name: name, Email: em, Origin: "vcard"})
+			}
+			name, emails = "", nil
+		}
+	}
+	return out
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	unorm "golang.org/x/text/unicode/norm"
+)
+
+// =================== Obfuskations-Grammatik ===================
+//
+// Verschleierte Adressen („max (at) uni-stuttgart punkt de“, „li点wei＠pku.edu.cn“)
+// werden datengetrieben erkannt: pro Sprache eine Tabelle mit den Wörtern für
+// „@“ und „.“. Daraus wird EIN Regex gebaut, der von allen symbolischen
+// Extraktoren (Colly, Chromedp, PDF) genutzt wird.
+
+// obfToken ist ein Ersatzwort für '@' bzw. '.'.
+// Loose=false → nur in Klammern gültig („(a)“, „[ad]“), sonst zu mehrdeutig.
+// Einbuchstabige Klammerwörter zusätzlich nur ohne Leerraum („max(a)uni.de“),
+// sonst kollidieren sie mit Aufzählungen („item (a) foo.bar“).
+type obfToken struct {
+	Word  string
+	Loose bool
+}
+
+type obfuscationLang struct {
+	Code string
+	At   []obfToken
+	Dot  []obfToken
+}
+
+var obfuscationGrammar = []obfuscationLang{
+	{Code: "en",
+		At:  []obfToken{{"at", true}, {"a", false}},
+		Dot: []obfToken{{"dot", true}, {"period", false}}},
+	{Code: "de",
+		At:  []obfToken{{"ät", true}, {"ad", false}, {"bei", false}, {"klammeraffe", true}},
+		Dot: []obfToken{{"punkt", true}}},
+	{Code: "fr",
+		At:  []obfToken{{"chez", false}, {"arobase", true}, {"arobas", true}},
+		Dot: []obfToken{{"point", true}}},
+	{Code: "es",
+		At:  []obfToken{{"arroba", true}},
+		Dot: []obfToken{{"punto", true}}},
+	{Code: "it",
+		At:  []obfToken{{"chiocciola", true}},
+		Dot: []obfToken{{"punto", true}}},
+	{Code: "nl",
+		At:  []obfToken{{"apenstaartje", true}},
+		Dot: []obfToken{{"punt", true}}},
+	{Code: "zh",
+		At:  []obfToken{{"艾特", true}},
+		Dot: []obfToken{{"点", true}, {"點", true}}},
+	{Code: "ja",
+		At:  []obfToken{{"アット", true}},
+		Dot: []obfToken{{"ドット", true}}},
+	// reine Symbole (nach Homoglyph-Normalisierung)
+	{Code: "sym",
+		At:  []obfToken{{"@", true}},
+		Dot: []obfToken{{".", true}}},
+}
+
+// Homoglyphen / Ersatzzeichen, die NFKC nicht auf ASCII abbildet.
+var homoglyphReplacer = strings.NewReplacer(
+	// Punkt-Varianten
+	"•", ".", "·", ".", "∙", ".", "。", ".", "｡", ".", "․", ".", "‧", ".",
+	// @-Varianten
+	"﹫", "@", "Ⓐ", "@",
+	// Bindestrich-Varianten
+	"‐", "-", "‑", "-", "‒", "-", "–", "-", "−", "-",
+	// kyrillisch / griechisch → lateinisch (nur optisch identische Zeichen)
+	"а", "a", "е", "e", "о", "o", "р", "p", "с", "c", "х", "x", "у", "y", "і", "i", "ј", "j", "ѕ", "s",
+	"ο", "o", "α", "a", "ν", "v", "ι", "i", "κ", "k", "τ", "t",
+	// unsichtbare Zeichen
+	"\u200b", "", "\u200c", "", "\u200d", "", "\u2060", "", "\ufeff", "", "\u00ad", "",
+)
+
+// normalizeHomoglyphs faltet Vollbreite (＠, ．, ｕｎｉ) per NFKC und ersetzt
+// optisch gleiche Zeichen durch ihr ASCII-Pendant.
+func normalizeHomoglyphs(s string) string {
+	if isPlainASCII(s) {
+		return s
+	}
+	s = unorm.NFKC.String(s)
+	return homoglyphReplacer.Replace(s)
+}
+
+func isPlainASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	reObfuscated *regexp.Regexp // local AT domain
+	reObfDotSep  *regexp.Regexp // Punkt-Trenner innerhalb der Domain
+	reObfHint    *regexp.Regexp // schneller Vorfilter
+
+	reObfMultiDot = regexp.MustCompile(`\.{2,}`) // doppelte Punkte nach dem Zusammensetzen
+)
+
+func init() {
+	reObfuscated, reObfDotSep, reObfHint = buildObfuscationRegex(obfuscationGrammar)
+}
+
+// buildObfuscationRegex erzeugt aus der Grammatik die Muster.
+// Schema:  local  AT  label (DOT label)+
+//
+//	AT  = [(\[{<] at-wort [)\]}>]  |  ␣loses-at-wort␣  |  @
+//	DOT = [(\[{<] dot-wort [)\]}>] |  ␣loses-dot-wort␣ |  .   (Punkt ohne Leerraum)
+func buildObfuscationRegex(grammar []obfuscationLang) (full, dotSep, hint *regexp.Regexp) {
+	var atBr, atTight, atLoose, dotBr, dotLoose []string
+	seen := map[string]struct{}{}
+	add := func(dst *[]string, key, pattern string) {
+		if _, ok := seen[key+"|"+pattern]; ok {
+			return
+		}
+		seen[key+"|"+pattern] = struct{}{}
+		*dst = append(*dst, pattern)
+	}
+	for _, lang := range grammar {
+		for _, t := range lang.At {
+			if !t.Loose && utf8.RuneCountInString(t.Word) == 1 {
+				add(&atTight, "atTight", regexp.QuoteMeta(t.Word))
+				continue
+			}
+			add(&atBr, "at", regexp.QuoteMeta(t.Word))
+			if t.Loose {
+				add(&atLoose, "atLoose", looseAlt(t.Word))
+			}
+		}
+		for _, t := range lang.Dot {
+			add(&dotBr, "dot", regexp.QuoteMeta(t.Word))
+			if t.Loose {
+				add(&dotLoose, "dotLoose", looseAlt(t.Word))
+			}
+		}
+	}
+
+	opn, cls := `[\(\[\{<]`, `[\)\]\}>]`
+	at := `(?:\s*` + opn + `\s*(?:` + strings.Join(atBr, "|") + `)\s*` + cls + `\s*` +
+		`|` + opn + `(?:` + strings.Join(atTight, "|") + `)` + cls +
+		`|` + strings.Join(atLoose, "|") + `)`
+	dot := `(?:\s*` + opn + `\s*(?:` + strings.Join(dotBr, "|") + `)\s*` + cls + `\s*` +
+		`|` + strings.Join(dotLoose, "|") + `)`
+
+	label := `[a-z0-9](?:[a-z0-9\-]{0,61}[a-z0-9])?`
+	full = regexp.MustCompile(`(?i)([a-z0-9][a-z0-9._%+\-]{0,63})` + at + `(` + label + `(?:` + dot + label + `)+)`)
+	dotSep = regexp.MustCompile(`(?i)` + dot)
+
+	hintWords := append(append(append([]string{}, atBr...), atTight...), dotBr...)
+	hint = regexp.MustCompile(`(?i)(?:` + opn + `\s*(?:` + strings.Join(hintWords, "|") + `)\s*` + cls +
+		`|` + strings.Join(atLoose, "|") + `)`)
+	return
+}
+
+// looseAlt liefert das Teilmuster für ein Wort. Lose ASCII-Wörter brauchen
+// Leerraum auf beiden Seiten („max at uni“), Symbole und CJK-Wörter nicht.
+// Der wörtliche Punkt steht ohne Leerraum, sonst würde ein Satzende
+// („tum.de. Next“) in die Domain gezogen.
+func looseAlt(word string) string {
+	q := regexp.QuoteMeta(word)
+	switch {
+	case word == ".":
+		return q
+	case isPlainASCII(word) && len(word) > 1:
+		return `\s+` + q + `\s+`
+	}
+	return `\s*` + q + `\s*`
+}
+
+// extractObfuscatedEmails liefert alle Adressen, die sich nach Normalisierung
+// über die Grammatik zusammensetzen lassen (ohne Plausibilitätsfilter).
+func extractObfuscatedEmails(text string) []string {
+	text = normalizeHomoglyphs(text)
+	if !reObfHint.MatchString(text) {
+		return nil
+	}
+	matches := reObfuscated.FindAllStringSubmatch(text, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(matches))
+	seen := make(map[string]struct{}, len(matches))
+	for _, m := range matches {
+		local := strings.ToLower(strings.Trim(m[1], "."))
+		if !reLocalOK.MatchString(local) {
+			continue
+		}
+		d := reObfDotSep.ReplaceAllString(strings.ToLower(m[2]), ".")
+		d = strings.Join(strings.Fields(d), "")
+		d = reObfMultiDot.ReplaceAllString(d, ".")
+		d = strings.Trim(d, ".")
+		if !validDomain(d) {
+			continue
+		}
+		em := local + "@" + d
+		if _, ok := seen[em]; ok {
+			continue
+		}
+		seen[em] = struct{}{}
+		out = append(out, em)
+	}
+	return out
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Eine Zeile je Obfuskations-Variante der Grammatik (symbolic.go), dazu
+// Fließtext, der nicht als Adresse gelesen werden darf. Geprüft wird die
+// vollständige Ergebnismenge.
+func TestExtractObfuscatedEmails(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"Mail: max.muster (at) uni-stuttgart (dot) de", []string{"max.muster@uni-stuttgart.de"}},
+		{"jdoe [at] cs [dot] bu [dot] edu", []string{"jdoe@cs.bu.edu"}},
+		{"contact: jdoe at mit dot edu", []string{"jdoe@mit.edu"}},
+		{"k.mueller (ät) tum.de", []string{"k.mueller@tum.de"}},
+		{"k.mueller [ad] tum punkt de", []string{"k.mueller@tum.de"}},
+		{"k.mueller(a)tum.de", []string{"k.mueller@tum.de"}},
+		{"k.mueller {bei} kit [punkt] edu", []string{"k.mueller@kit.edu"}},
+		{"jean.dupont (chez) inria point fr", []string{"jean.dupont@inria.fr"}},
+		{"jean.dupont arobase inria.fr", []string{"jean.dupont@inria.fr"}},
+		{"jgarcia arroba upm punto es", []string{"jgarcia@upm.es"}},
+		{"m.rossi chiocciola polimi punto it", []string{"m.rossi@polimi.it"}},
+		{"j.jansen apenstaartje tudelft punt nl", []string{"j.jansen@tudelft.nl"}},
+		{"wangwei＠pku.edu.cn", []string{"wangwei@pku.edu.cn"}},
+		{"wangwei@pku点edu点cn", []string{"wangwei@pku.edu.cn"}},
+		{"wangwei艾特pku點edu點cn", []string{"wangwei@pku.edu.cn"}},
+		{"tanaka アット u-tokyo ドット ac ドット jp", []string{"tanaka@u-tokyo.ac.jp"}},
+		{"smith@cs•ucla•edu", []string{"smith@cs.ucla.edu"}},
+		{"ｓｍｉｔｈ＠ｃｓ．ｕｃｌａ．ｅｄｕ", []string{"smith@cs.ucla.edu"}},
+		{"smith@cs。ucla。edu", []string{"smith@cs.ucla.edu"}},
+		{"ivаnov@msu.ru", []string{"ivanov@msu.ru"}}, // kyrillisches „а“
+		{"bob\u200b@\u200bmit.edu", []string{"bob@mit.edu"}},
+		// Satzende und Aufzählungen
+		{"k.mueller (at) tum.de. Next sentence", []string{"k.mueller@tum.de"}},
+		{"I was at home. Then", nil},
+		{"Meeting at Stanford. University", nil},
+		{"see item (a) foo.bar", nil},
+		{"we met at noon and left at five", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := extractObfuscatedEmails(tt.input)
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("extractObfuscatedEmails(%q) = %v, erwartet %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
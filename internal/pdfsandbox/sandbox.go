@@ -0,0 +1,64 @@
+// Package pdfsandbox setzt harte Grenzen für die PDF-Worker der klassischen
+// Pipeline: rlimits für Adressraum, CPU-Zeit je Auftrag und offene Dateien
+// sowie optional einen seccomp-Filter (kein Netzwerk, kein exec, kein
+// ptrace). Der Worker wendet sie beim Start auf sich selbst an, bevor er den
+// ersten (nicht vertrauenswürdigen) PDF-Auftrag liest.
+//
+// Eigenes Paket, weil die Plattformteile Build-Tags brauchen und die
+// Pipeline dateiweise gebaut wird (Leerzeichen im Verzeichnisnamen).
+package pdfsandbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+const (
+	EnvVar = "PDFWORKER_LIMITS" // JSON mit Limits für das Child
+
+	ExitCPU   = 3 // Worker beendet sich nach SIGXCPU
+	ExitSetup = 4 // Limits/Sandbox ließen sich nicht einrichten
+)
+
+// Limits: Grenzen, die der Worker sich beim Start selbst setzt (0 = keine).
+type Limits struct {
+	AddressSpaceMB int  `json:"as_mb"`
+	CPUPerJobSec   int  `json:"cpu_job_s"`
+	MaxJobs        int  `json:"max_jobs"` // für das harte CPU-Limit über die Lebensdauer
+	NoFile         int  `json:"nofile"`
+	Seccomp        bool `json:"seccomp"`
+}
+
+// Env liefert die Umgebungsvariable für den Worker-Start.
+func (l Limits) Env() string {
+	b, _ := json.Marshal(l)
+	return EnvVar + "=" + string(b)
+}
+
+// CPUHardSec: hartes CPU-Limit über die gesamte Lebensdauer (Kernel → SIGKILL); 0 = keins.
+func (l Limits) CPUHardSec() int {
+	if l.CPUPerJobSec <= 0 || !Supported() {
+		return 0
+	}
+	if l.MaxJobs < 1 {
+		l.MaxJobs = 1
+	}
+	return l.CPUPerJobSec*(l.MaxJobs+1) + 5
+}
+
+// Setup läuft im Worker vor dem ersten Auftrag; Fehler beenden den Worker mit ExitSetup.
+func Setup() Limits {
+	var l Limits
+	if s := os.Getenv(EnvVar); s != "" {
+		if err := json.Unmarshal([]byte(s), &l); err != nil {
+			fmt.Fprintf(os.Stderr, "pdf-worker: Limits unlesbar: %v\n", err)
+			os.Exit(ExitSetup)
+		}
+	}
+	if err := apply(l); err != nil {
+		fmt.Fprintf(os.Stderr, "pdf-worker: sandbox: %v\n", err)
+		os.Exit(ExitSetup)
+	}
+	return l
+}
@@ -0,0 +1,146 @@
+//go:build linux
+
+package pdfsandbox
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"syscall"
+	"unsafe"
+)
+
+// seccomp-Konstanten (linux/seccomp.h, linux/audit.h)
+const (
+	prSetNoNewPrivs        = 38
+	seccompSetModeFilter   = 1
+	seccompFilterFlagTSync = 1
+	seccompRetAllow        = 0x7fff0000
+	seccompRetErrno        = 0x00050000
+	seccompRetKillProcess  = 0x80000000
+	seccompDataArchOffset  = 4
+	seccompDataNrOffset    = 0
+	x32SyscallBit          = 0x40000000
+	auditArchX86_64        = 0xc000003e
+	auditArchAArch64       = 0xc00000b7
+	sysSeccompAMD64        = 317
+	sysSeccompARM64        = 277
+)
+
+// seccompDeny: Syscalls, die ein PDF-Parser nie braucht (Netzwerk, exec, Debugging, Namespaces).
+var seccompDeny = map[string]struct {
+	arch    uint32
+	seccomp uintptr
+	nrs     []uint32
+}{
+	// socket connect accept bind listen accept4 execve execveat fork vfork ptrace mount umount2
+	// unshare setns kexec_load process_vm_readv process_vm_writev bpf perf_event_open init_module finit_module
+	"amd64": {auditArchX86_64, sysSeccompAMD64, []uint32{41, 42, 43, 49, 50, 288, 59, 322, 57, 58, 101, 165, 166, 272, 308, 246, 310, 311, 321, 298, 175, 313}},
+	"arm64": {auditArchAArch64, sysSeccompARM64, []uint32{198, 203, 202, 200, 201, 242, 221, 281, 117, 40, 39, 97, 268, 104, 270, 271, 280, 241, 105, 273}},
+}
+
+// Supported meldet, ob rlimits und seccomp auf dieser Plattform greifen.
+func Supported() bool { return true }
+
+// ConfigureCmd: Worker stirbt mit dem Parent.
+func ConfigureCmd(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Pdeathsig: syscall.SIGKILL}
+}
+
+// apply setzt rlimits und (optional) den seccomp-Filter im laufenden Worker.
+func apply(l Limits) error {
+	if l.NoFile > 0 {
+		if err := setHardLimit(syscall.RLIMIT_NOFILE, uint64(l.NoFile), uint64(l.NoFile)); err != nil {
+			return fmt.Errorf("rlimit NOFILE: %w", err)
+		}
+	}
+	if l.AddressSpaceMB > 0 {
+		as := uint64(l.AddressSpaceMB) << 20
+		if err := setHardLimit(syscall.RLIMIT_AS, as, as); err != nil {
+			return fmt.Errorf("rlimit AS: %w", err)
+		}
+	}
+	if l.CPUPerJobSec > 0 {
+		// SIGXCPU ignoriert Go ohne Handler; der Worker beendet sich mit eigenem Exit-Code
+		ch := make(chan os.Signal, 1)
+		signal.Notify(ch, syscall.SIGXCPU)
+		go func() {
+			<-ch
+			fmt.Fprintln(os.Stderr, "pdf-worker: cpu limit exceeded")
+			os.Exit(ExitCPU)
+		}()
+		hard := uint64(l.CPUHardSec())
+		if err := setHardLimit(syscall.RLIMIT_CPU, uint64(l.CPUPerJobSec), hard); err != nil {
+			return fmt.Errorf("rlimit CPU: %w", err)
+		}
+	}
+	if l.Seccomp {
+		return installSeccomp()
+	}
+	return nil
+}
+
+// ArmJob: weiches CPU-Limit = bisher verbrauchte Zeit + Budget eines Auftrags.
+func ArmJob(l Limits) {
+	if l.CPUPerJobSec <= 0 {
+		return
+	}
+	var ru syscall.Rusage
+	if syscall.Getrusage(syscall.RUSAGE_SELF, &ru) != nil {
+		return
+	}
+	used := uint64(ru.Utime.Sec + ru.Stime.Sec + 1)
+	var cur syscall.Rlimit
+	if syscall.Getrlimit(syscall.RLIMIT_CPU, &cur) != nil {
+		return
+	}
+	soft := used + uint64(l.CPUPerJobSec)
+	if soft > cur.Max {
+		soft = cur.Max
+	}
+	_ = syscall.Setrlimit(syscall.RLIMIT_CPU, &syscall.Rlimit{Cur: soft, Max: cur.Max})
+}
+
+func setHardLimit(resource int, soft, hard uint64) error {
+	return syscall.Setrlimit(resource, &syscall.Rlimit{Cur: soft, Max: hard})
+}
+
+// installSeccomp lädt einen Denylist-Filter für alle Threads: verbotene Syscalls liefern EPERM.
+func installSeccomp() error {
+	spec, ok := seccompDeny[runtime.GOARCH]
+	if !ok {
+		return fmt.Errorf("seccomp: Architektur %s nicht unterstützt", runtime.GOARCH)
+	}
+	n := len(spec.nrs)
+	prog := []syscall.SockFilter{
+		{Code: syscall.BPF_LD | syscall.BPF_W | syscall.BPF_ABS, K: seccompDataArchOffset},
+		{Code: syscall.BPF_JMP | syscall.BPF_JEQ | syscall.BPF_K, Jt: 1, K: spec.arch},
+		{Code: syscall.BPF_RET | syscall.BPF_K, K: seccompRetKillProcess},
+		{Code: syscall.BPF_LD | syscall.BPF_W | syscall.BPF_ABS, K: seccompDataNrOffset},
+		// x32-ABI (amd64) komplett sperren
+		{Code: syscall.BPF_JMP | syscall.BPF_JGE | syscall.BPF_K, Jt: uint8(n + 1), K: x32SyscallBit},
+	}
+	for i, nr := range spec.nrs {
+		prog = append(prog, syscall.SockFilter{Code: syscall.BPF_JMP | syscall.BPF_JEQ | syscall.BPF_K, Jt: uint8(n - i), K: nr})
+	}
+	prog = append(prog,
+		syscall.SockFilter{Code: syscall.BPF_RET | syscall.BPF_K, K: seccompRetAllow},
+		syscall.SockFilter{Code: syscall.BPF_RET | syscall.BPF_K, K: seccompRetErrno | uint32(syscall.EPERM)},
+	)
+	fprog := syscall.SockFprog{Len: uint16(len(prog)), Filter: &prog[0]}
+
+	if _, _, e := syscall.RawSyscall(syscall.SYS_PRCTL, prSetNoNewPrivs, 1, 0); e != 0 {
+		return fmt.Errorf("seccomp: no_new_privs: %w", e)
+	}
+	r, _, e := syscall.RawSyscall(spec.seccomp, seccompSetModeFilter, seccompFilterFlagTSync, uintptr(unsafe.Pointer(&fprog)))
+	if e != 0 {
+		return fmt.Errorf("seccomp: Filter laden: %w", e)
+	}
+	if r != 0 { // TSYNC: Thread r ließ sich nicht synchronisieren
+		return fmt.Errorf("seccomp: Thread %d nicht synchronisiert", r)
+	}
+	runtime.KeepAlive(prog)
+	return nil
+}
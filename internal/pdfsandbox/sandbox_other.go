@@ -0,0 +1,26 @@
+//go:build !linux
+
+package pdfsandbox
+
+import (
+	"errors"
+	"os/exec"
+)
+
+// Außerhalb von Linux bleibt es bei GOMEMLIMIT, Timeout und Recycling.
+
+// Supported meldet, ob rlimits und seccomp auf dieser Plattform greifen.
+func Supported() bool { return false }
+
+// ConfigureCmd ist außerhalb von Linux ohne Wirkung.
+func ConfigureCmd(cmd *exec.Cmd) {}
+
+// ArmJob ist außerhalb von Linux ohne Wirkung.
+func ArmJob(l Limits) {}
+
+func apply(l Limits) error {
+	if l.Seccomp {
+		return errors.New("seccomp gibt es nur unter Linux")
+	}
+	return nil
+}